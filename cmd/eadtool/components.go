@@ -0,0 +1,73 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/nyulibraries/dlts-finding-aids-ead-go-packages/ead/modify"
+)
+
+func runComponents(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("components", flag.ContinueOnError)
+	outputDir := flags.String("o", "", "write the converted EADs to `directory` (required for multiple EADs)")
+	numbered := flags.Bool("numbered", false, "rename the components to numbered components, <c01> to <c12>, instead of <c>")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: eadtool components [-o DIR] [-numbered] PATH...")
+		flags.PrintDefaults()
+	}
+
+	files, code := parseFlagsAndInputs(flags, args, stderr)
+	if code >= 0 {
+		return code
+	}
+
+	if *outputDir == "" && len(files) > 1 {
+		fmt.Fprintln(stderr, "eadtool components: -o is required when converting more than one EAD")
+		return exitUsage
+	}
+
+	if *outputDir != "" {
+		if err := checkOutputPaths(*outputDir, files, ".xml"); err != nil {
+			fmt.Fprintf(stderr, "eadtool components: %s\n", err)
+			return exitUsage
+		}
+	}
+
+	convert := modify.UnnumberComponents
+	if *numbered {
+		convert = modify.NumberComponents
+	}
+
+	exitCode := exitOK
+	for _, file := range files {
+		EADXML, err := os.ReadFile(file)
+		if err != nil {
+			fmt.Fprintf(stderr, "eadtool components: %s: %s\n", file, err)
+			exitCode = exitFailure
+			continue
+		}
+
+		converted, errors := convert(EADXML)
+		if len(errors) > 0 {
+			fmt.Fprintf(stderr, "eadtool components: %s: %s\n", file, strings.Join(errors, ": "))
+			exitCode = exitFailure
+			continue
+		}
+
+		if *outputDir == "" {
+			fmt.Fprint(stdout, converted)
+			continue
+		}
+
+		err = writeOutputFile(outputPath(*outputDir, file, ".xml"), []byte(converted))
+		if err != nil {
+			fmt.Fprintf(stderr, "eadtool components: %s: %s\n", file, err)
+			exitCode = exitFailure
+		}
+	}
+
+	return exitCode
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/nyulibraries/dlts-finding-aids-ead-go-packages/ead"
+)
+
+func runCoverage(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("coverage", flag.ContinueOnError)
+	jsonOutput := flags.Bool("json", false, "print the coverage report as JSON")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: eadtool coverage [-json] PATH...")
+		flags.PrintDefaults()
+	}
+
+	files, code := parseFlagsAndInputs(flags, args, stderr)
+	if code >= 0 {
+		return code
+	}
+
+	exitCode := exitOK
+	coverage := &ead.Coverage{Items: []*ead.CoverageItem{}}
+	for _, file := range files {
+		err := addCoverage(coverage, file)
+		if err != nil {
+			fmt.Fprintf(stderr, "eadtool coverage: %s: %s\n", file, err)
+			exitCode = exitFailure
+		}
+	}
+
+	if *jsonOutput {
+		if err := writeJSON(stdout, coverage); err != nil {
+			fmt.Fprintf(stderr, "eadtool coverage: %s\n", err)
+			return exitFailure
+		}
+	} else {
+		printCoverage(stdout, coverage)
+	}
+
+	return exitCode
+}
+
+func addCoverage(coverage *ead.Coverage, file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return coverage.Add(f)
+}
+
+func printCoverage(w io.Writer, coverage *ead.Coverage) {
+	fmt.Fprintf(w, "EADs: %d\n", coverage.Files)
+	if len(coverage.Items) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "%8s %8s  %-40s %s\n", "COUNT", "EADS", "PATH", "KIND")
+	for _, item := range coverage.Items {
+		fmt.Fprintf(w, "%8d %8d  %-40s %s\n", item.Count, item.Files, item.Path, item.Kind)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/nyulibraries/dlts-finding-aids-ead-go-packages/ead/modify"
+)
+
+func runFABify(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("fabify", flag.ContinueOnError)
+	outputDir := flags.String("o", "", "write the FABified EADs to `directory` (required for multiple EADs)")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: eadtool fabify [-o DIR] PATH...")
+		flags.PrintDefaults()
+	}
+
+	files, code := parseFlagsAndInputs(flags, args, stderr)
+	if code >= 0 {
+		return code
+	}
+
+	if *outputDir == "" && len(files) > 1 {
+		fmt.Fprintln(stderr, "eadtool fabify: -o is required when FABifying more than one EAD")
+		return exitUsage
+	}
+
+	if *outputDir != "" {
+		if err := checkOutputPaths(*outputDir, files, ".xml"); err != nil {
+			fmt.Fprintf(stderr, "eadtool fabify: %s\n", err)
+			return exitUsage
+		}
+	}
+
+	exitCode := exitOK
+	for _, file := range files {
+		EADXML, err := os.ReadFile(file)
+		if err != nil {
+			fmt.Fprintf(stderr, "eadtool fabify: %s: %s\n", file, err)
+			exitCode = exitFailure
+			continue
+		}
+
+		fabified, errors := modify.FABifyEAD(EADXML)
+		if len(errors) > 0 {
+			fmt.Fprintf(stderr, "eadtool fabify: %s: %s\n", file, strings.Join(errors, ": "))
+			exitCode = exitFailure
+			continue
+		}
+
+		if *outputDir == "" {
+			fmt.Fprint(stdout, fabified)
+			continue
+		}
+
+		err = writeOutputFile(outputPath(*outputDir, file, ".xml"), []byte(fabified))
+		if err != nil {
+			fmt.Fprintf(stderr, "eadtool fabify: %s: %s\n", file, err)
+			exitCode = exitFailure
+		}
+	}
+
+	return exitCode
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/nyulibraries/dlts-finding-aids-ead-go-packages/ead"
+)
+
+func runIJSON(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("ijson", flag.ContinueOnError)
+	outputDir := flags.String("o", "", "write one <name>.json file per EAD to `directory` (required for multiple EADs)")
+	presentationComponents := flags.Bool("presentation-components", false, "add presentation components to the component hierarchy")
+	dateSummary := flags.Bool("date-summary", false, "add the aggregated collection date range (datesummary)")
+	componentHierarchy := flags.Bool("component-hierarchy", false, "add hierarchical sort keys (sortkey) to components")
+	themeID := flags.String("theme-id", "", "set pubinfo.themeid")
+	repoID := flags.String("repo-id", "", "set pubinfo.reposidentifier")
+	rendererPath := flags.String("renderer", "", "YAML or JSON HTML renderer `file` for the value fields")
+	lenient := flags.Bool("lenient", false, "do not fail on unsupported elements, and print a warning for each element and attribute that is skipped")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: eadtool ijson [-o DIR] [-presentation-components] [-date-summary] [-component-hierarchy] [-theme-id ID] [-repo-id ID] [-renderer FILE] [-lenient] PATH...")
+		flags.PrintDefaults()
+	}
+
+	files, code := parseFlagsAndInputs(flags, args, stderr)
+	if code >= 0 {
+		return code
+	}
+
+	if *outputDir == "" && len(files) > 1 {
+		fmt.Fprintln(stderr, "eadtool ijson: -o is required when converting more than one EAD")
+		return exitUsage
+	}
+
+	if *outputDir != "" {
+		if err := checkOutputPaths(*outputDir, files, ".json"); err != nil {
+			fmt.Fprintf(stderr, "eadtool ijson: %s\n", err)
+			return exitUsage
+		}
+	}
+
+	var renderer *ead.Renderer
+	if *rendererPath != "" {
+		var err error
+		renderer, err = ead.ReadRendererFromFile(*rendererPath)
+		if err != nil {
+			fmt.Fprintf(stderr, "eadtool ijson: %s\n", err)
+			return exitUsage
+		}
+	}
+
+	mode := ead.Strict
+	if *lenient {
+		mode = ead.Lenient
+	}
+
+	exitCode := exitOK
+	for _, file := range files {
+		jsonData, warnings, err := convertToIJSON(file, mode, renderer, *presentationComponents, *dateSummary, *componentHierarchy, *themeID, *repoID)
+		if err != nil {
+			fmt.Fprintf(stderr, "eadtool ijson: %s: %s\n", file, err)
+			exitCode = exitFailure
+			continue
+		}
+
+		for _, warning := range warnings {
+			fmt.Fprintf(stderr, "eadtool ijson: %s: warning: %s\n", file, warning)
+		}
+
+		if *outputDir == "" {
+			fmt.Fprintf(stdout, "%s\n", jsonData)
+			continue
+		}
+
+		err = writeOutputFile(outputPath(*outputDir, file, ".json"), append(jsonData, '\n'))
+		if err != nil {
+			fmt.Fprintf(stderr, "eadtool ijson: %s: %s\n", file, err)
+			exitCode = exitFailure
+		}
+	}
+
+	return exitCode
+}
+
+// convertToIJSON returns the iJSON of the EAD file, and the warnings recorded
+// by lenient decoding.  A nil renderer is the default rendering.
+func convertToIJSON(file string, mode ead.DecodeMode, renderer *ead.Renderer, presentationComponents bool, dateSummary bool, componentHierarchy bool, themeID string, repoID string) ([]byte, []ead.Warning, error) {
+	EADXML, err := os.ReadFile(file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sut, err := ead.Decode(bytes.NewReader(EADXML), mode)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sut.RunInfo.PkgVersion = ead.Version
+	sut.RunInfo.TimeStamp = time.Now()
+	sut.RunInfo.SourceFile = file
+	sut.RunInfo.SourceFileHash = fmt.Sprintf("sha256:%x", sha256.Sum256(EADXML))
+	sut.PubInfo.SetPubInfo(themeID, repoID)
+
+	if presentationComponents && sut.ArchDesc != nil && sut.ArchDesc.DSC != nil {
+		sut.InitPresentationComponents()
+	}
+
+	if componentHierarchy {
+		sut.InitComponentHierarchy()
+	}
+
+	// after the presentation components are added, and before the date
+	// summary titles are rendered
+	sut.SetRenderer(renderer)
+
+	if dateSummary {
+		err = sut.InitDateSummary()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	jsonData, err := json.MarshalIndent(sut, "", "    ")
+	return jsonData, sut.Warnings, err
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// expandInputs converts the PATH arguments into a sorted, de-duplicated list of files.
+// Directories are walked recursively for *.xml files, and arguments that
+// contain glob metacharacters are expanded with filepath.Glob.
+func expandInputs(args []string) ([]string, error) {
+	seen := map[string]bool{}
+	var files []string
+
+	add := func(path string) {
+		if !seen[path] {
+			seen[path] = true
+			files = append(files, path)
+		}
+	}
+
+	for _, arg := range args {
+		var paths []string
+		if strings.ContainsAny(arg, "*?[") {
+			matches, err := filepath.Glob(arg)
+			if err != nil {
+				return nil, fmt.Errorf("invalid glob pattern %q: %s", arg, err)
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no files match %q", arg)
+			}
+			paths = matches
+		} else {
+			paths = []string{arg}
+		}
+
+		for _, path := range paths {
+			info, err := os.Stat(path)
+			if err != nil {
+				return nil, err
+			}
+
+			if !info.IsDir() {
+				add(path)
+				continue
+			}
+
+			err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if !d.IsDir() && strings.EqualFold(filepath.Ext(p), ".xml") {
+					add(p)
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// parseFlagsAndInputs parses the command flags and expands the remaining arguments.
+// A non-negative exit code is returned if the command should exit immediately.
+func parseFlagsAndInputs(flags *flag.FlagSet, args []string, stderr io.Writer) ([]string, int) {
+	flags.SetOutput(stderr)
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return nil, exitOK
+		}
+		return nil, exitUsage
+	}
+
+	if flags.NArg() == 0 {
+		fmt.Fprintf(stderr, "eadtool %s: no PATH specified\n", flags.Name())
+		flags.Usage()
+		return nil, exitUsage
+	}
+
+	files, err := expandInputs(flags.Args())
+	if err != nil {
+		fmt.Fprintf(stderr, "eadtool %s: %s\n", flags.Name(), err)
+		// a missing input file is a processing error like any other file error
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, exitFailure
+		}
+		return nil, exitUsage
+	}
+
+	if len(files) == 0 {
+		fmt.Fprintf(stderr, "eadtool %s: no EAD files found\n", flags.Name())
+		return nil, exitUsage
+	}
+
+	return files, -1
+}
+
+// outputPath returns the path of the output file for an input file.
+// The output file has the same base name as the input file, with the extension replaced.
+func outputPath(outputDir string, inputPath string, extension string) string {
+	base := filepath.Base(inputPath)
+	base = strings.TrimSuffix(base, filepath.Ext(base))
+	return filepath.Join(outputDir, base+extension)
+}
+
+// checkOutputPaths returns an error if two input files have the same output
+// file, e.g., a/mss_460.xml and b/mss_460.xml, so that neither output file
+// is silently overwritten
+func checkOutputPaths(outputDir string, files []string, extension string) error {
+	inputs := map[string]string{}
+	for _, file := range files {
+		path := outputPath(outputDir, file, extension)
+		if other, ok := inputs[path]; ok {
+			return fmt.Errorf("%s and %s have the same output file %s", other, file, path)
+		}
+		inputs[path] = file
+	}
+	return nil
+}
+
+// writeOutputFile writes data to path, creating the parent directories as needed
+func writeOutputFile(path string, data []byte) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
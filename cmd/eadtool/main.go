@@ -0,0 +1,86 @@
+// Command eadtool validates, converts, and FABifies EAD files.
+//
+// Usage:
+//
+//	eadtool validate [-json] [-profile FILE] [-workers N] PATH...
+//	eadtool ijson    [-o DIR] [-presentation-components] [-date-summary] [-component-hierarchy] [-theme-id ID] [-repo-id ID] [-renderer FILE] [-lenient] PATH...
+//	eadtool fabify   [-o DIR] PATH...
+//	eadtool components [-o DIR] [-numbered] PATH...
+//	eadtool stats    [-json] PATH...
+//	eadtool pulllist [-json] PATH...
+//	eadtool coverage [-json] PATH...
+//
+// Each PATH may be an EAD file, a directory (all *.xml files in the directory
+// tree are processed), or a glob pattern.  The -o output files are named
+// after the input files, so inputs with the same file name are rejected.
+//
+// Exit status is 0 on success, 1 if any EAD is missing or failed validation
+// or processing, and 2 for usage errors.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	exitOK      = 0
+	exitFailure = 1
+	exitUsage   = 2
+)
+
+type command struct {
+	name        string
+	description string
+	run         func(args []string, stdout io.Writer, stderr io.Writer) int
+}
+
+var commands = []command{
+	{"validate", "validate EADs per the EAD 2002 schema and the EAD validation criteria", runValidate},
+	{"ijson", "convert EADs to intermediate JSON (iJSON)", runIJSON},
+	{"fabify", "modify EADs so that they are compatible with the FAB indexer", runFABify},
+	{"components", "convert numbered components (<c01> to <c12>) to <c>, or <c> to numbered components", runComponents},
+	{"stats", "print component and digital object statistics for EADs", runStats},
+	{"pulllist", "print a container pull list (CSV) for reading room retrieval", runPullList},
+	{"coverage", "report the elements and attributes of EADs that are not part of the data model", runCoverage},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return exitUsage
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			return cmd.run(args[1:], stdout, stderr)
+		}
+	}
+
+	switch args[0] {
+	case "-h", "-help", "--help", "help":
+		usage(stdout)
+		return exitOK
+	}
+
+	fmt.Fprintf(stderr, "eadtool: unknown command %q\n\n", args[0])
+	usage(stderr)
+	return exitUsage
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: eadtool <command> [flags] PATH...")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.description)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Each PATH may be an EAD file, a directory, or a glob pattern.")
+	fmt.Fprintln(w, `Run "eadtool <command> -h" for command flags.`)
+}
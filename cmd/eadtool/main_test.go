@@ -0,0 +1,410 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nyulibraries/dlts-finding-aids-ead-go-packages/ead"
+	"github.com/nyulibraries/dlts-finding-aids-ead-go-packages/ead/validate"
+)
+
+var validateFixturesDir = filepath.Join("..", "..", "ead", "validate", "testdata", "fixtures")
+var modifyFixturesDir = filepath.Join("..", "..", "ead", "modify", "testdata")
+
+var validEADPath = filepath.Join(validateFixturesDir, "mc_100.xml")
+var invalidEADPath = filepath.Join(validateFixturesDir, "mc_100-invalid-eadid-repository-role-relator-codes-unpublished-material.xml")
+
+func runEADTool(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func assertExitCode(t *testing.T, want int, got int, stderr string) {
+	if want != got {
+		t.Errorf("Expected exit code %d, got %d\nstderr:\n%s", want, got, stderr)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	code, _, stderr := runEADTool()
+	assertExitCode(t, exitUsage, code, stderr)
+
+	code, _, stderr = runEADTool("frobnicate", validEADPath)
+	assertExitCode(t, exitUsage, code, stderr)
+	if !strings.Contains(stderr, `unknown command "frobnicate"`) {
+		t.Errorf("Expected unknown command message, got:\n%s", stderr)
+	}
+
+	code, _, stderr = runEADTool("validate")
+	assertExitCode(t, exitUsage, code, stderr)
+
+	code, _, stderr = runEADTool("validate", filepath.Join(validateFixturesDir, "no-such-file.xml"))
+	assertExitCode(t, exitFailure, code, stderr)
+}
+
+func TestOutputPathCollision(t *testing.T) {
+	EADXML, err := os.ReadFile(validEADPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inputDir := t.TempDir()
+	for _, name := range []string{filepath.Join("a", "mc_100.xml"), filepath.Join("b", "mc_100.xml")} {
+		path := filepath.Join(inputDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, EADXML, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, command := range []string{"ijson", "fabify", "components"} {
+		outputDir := t.TempDir()
+		code, _, stderr := runEADTool(command, "-o", outputDir, inputDir)
+		assertExitCode(t, exitUsage, code, stderr)
+		if !strings.Contains(stderr, "have the same output file") {
+			t.Errorf("%s: expected an output file collision error, got:\n%s", command, stderr)
+		}
+
+		entries, err := os.ReadDir(outputDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(entries) != 0 {
+			t.Errorf("%s: expected no output files, got %d", command, len(entries))
+		}
+	}
+}
+
+func TestExpandInputs(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.xml", "b.XML", "notes.txt", filepath.Join("sub", "c.xml")} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("<ead/>"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := expandInputs([]string{
+		filepath.Join(dir, "sub", "c.xml"),
+		dir,
+		filepath.Join(dir, "*.xml"),
+		filepath.Join(dir, "notes.txt"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		filepath.Join(dir, "a.xml"),
+		filepath.Join(dir, "b.XML"),
+		filepath.Join(dir, "notes.txt"),
+		filepath.Join(dir, "sub", "c.xml"),
+	}
+	if strings.Join(want, "\n") != strings.Join(got, "\n") {
+		t.Errorf("Expected files:\n%s\n\nGot:\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+
+	_, err = expandInputs([]string{filepath.Join(dir, "*.json")})
+	if err == nil {
+		t.Errorf("Expected an error for a glob pattern without matches")
+	}
+}
+
+func TestValidateCommand(t *testing.T) {
+	code, stdout, stderr := runEADTool("validate", validEADPath)
+	assertExitCode(t, exitOK, code, stderr)
+	if stdout != validEADPath+": OK\n" {
+		t.Errorf("Unexpected output:\n%s", stdout)
+	}
+
+	code, stdout, stderr = runEADTool("validate", validEADPath, invalidEADPath)
+	assertExitCode(t, exitFailure, code, stderr)
+	for _, want := range []string{
+		invalidEADPath + ": INVALID",
+		"[error] invalid-eadid /ead[1]/eadheader[1]/eadid[1]",
+		"[error] unpublished-material /ead[1]/archdesc[1]/bioghist[1] (line 36, column 3)",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, stdout)
+		}
+	}
+}
+
+func TestValidateCommandJSON(t *testing.T) {
+	code, stdout, stderr := runEADTool("validate", "-json", validEADPath, invalidEADPath)
+	assertExitCode(t, exitFailure, code, stderr)
+
+	var results []validate.ValidationResult
+	if err := json.Unmarshal([]byte(stdout), &results); err != nil {
+		t.Fatalf("Unable to unmarshal JSON output: %s\n%s", err, stdout)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	for _, result := range results {
+		switch result.Source {
+		case validEADPath:
+			if !result.Valid() {
+				t.Errorf("Expected %s to be valid: %v", validEADPath, result)
+			}
+		case invalidEADPath:
+			if result.Valid() {
+				t.Errorf("Expected %s to be invalid: %v", invalidEADPath, result)
+			}
+		default:
+			t.Errorf("Unexpected result source: %s", result.Source)
+		}
+	}
+}
+
+func TestValidateCommandProfile(t *testing.T) {
+	profilePath := filepath.Join(t.TempDir(), "profile.yaml")
+	err := os.WriteFile(profilePath, []byte("rules:\n  eadid: false\n  repository: false\n  no-unpublished-material: false\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	code, stdout, stderr := runEADTool("validate", "-profile", profilePath, invalidEADPath)
+	assertExitCode(t, exitOK, code, stderr)
+	if stdout != invalidEADPath+": OK\n" {
+		t.Errorf("Unexpected output:\n%s", stdout)
+	}
+
+	code, _, stderr = runEADTool("validate", "-profile", filepath.Join("..", "..", "ead", "validate", "testdata", "profiles", "unknown-rule.yaml"), validEADPath)
+	assertExitCode(t, exitUsage, code, stderr)
+}
+
+func TestIJSONCommand(t *testing.T) {
+	code, stdout, stderr := runEADTool("ijson", "-theme-id", "cdf80c84-2d8a-4c0d-ac48-3b5dd3ac2a6e", "-repo-id", "fales", validEADPath)
+	assertExitCode(t, exitOK, code, stderr)
+
+	var got map[string]interface{}
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("Unable to unmarshal JSON output: %s\n%s", err, stdout)
+	}
+
+	runinfo := got["runinfo"].(map[string]interface{})
+	if runinfo["sourcefile"] != validEADPath {
+		t.Errorf("Expected runinfo.sourcefile %q, got %q", validEADPath, runinfo["sourcefile"])
+	}
+	pubinfo := got["pubinfo"].(map[string]interface{})
+	if pubinfo["reposidentifier"] != "fales" {
+		t.Errorf(`Expected pubinfo.reposidentifier "fales", got %q`, pubinfo["reposidentifier"])
+	}
+	if _, ok := got["datesummary"]; ok {
+		t.Errorf("Expected no datesummary without -date-summary")
+	}
+
+	code, stdout, stderr = runEADTool("ijson", "-date-summary", validEADPath)
+	assertExitCode(t, exitOK, code, stderr)
+	got = nil
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("Unable to unmarshal JSON output: %s\n%s", err, stdout)
+	}
+	if _, ok := got["datesummary"].(map[string]interface{}); !ok {
+		t.Errorf("Expected a datesummary with -date-summary")
+	}
+
+	code, stdout, stderr = runEADTool("ijson", "-component-hierarchy", validEADPath)
+	assertExitCode(t, exitOK, code, stderr)
+	if !strings.Contains(stdout, `"sortkey": "00001"`) {
+		t.Errorf("Expected component sort keys with -component-hierarchy")
+	}
+
+	code, stdout, stderr = runEADTool("ijson", "-renderer", filepath.Join("..", "..", "ead", "testdata", "renderer", "semantic.yaml"), validEADPath)
+	assertExitCode(t, exitOK, code, stderr)
+	if !strings.Contains(stdout, `class=\"fa-`) || strings.Contains(stdout, `class=\"ead-`) {
+		t.Errorf("Expected the -renderer class prefix in the value fields")
+	}
+
+	code, _, stderr = runEADTool("ijson", "-renderer", filepath.Join("..", "..", "ead", "testdata", "renderer", "unknown-setting.yaml"), validEADPath)
+	assertExitCode(t, exitUsage, code, stderr)
+
+	unsupportedElementEADPath := filepath.Join(t.TempDir(), "unsupported-element.xml")
+	err := os.WriteFile(unsupportedElementEADPath,
+		[]byte(`<ead><archdesc level="collection" audience="internal"><scopecontent><head01>Head</head01><p>Text</p></scopecontent></archdesc></ead>`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	code, _, stderr = runEADTool("ijson", unsupportedElementEADPath)
+	assertExitCode(t, exitFailure, code, stderr)
+
+	code, stdout, stderr = runEADTool("ijson", "-lenient", unsupportedElementEADPath)
+	assertExitCode(t, exitOK, code, stderr)
+	if !strings.Contains(stdout, `"Text"`) {
+		t.Errorf("Expected the -lenient iJSON to include the supported elements, got:\n%s", stdout)
+	}
+	for _, want := range []string{
+		"warning: skipped attribute: /ead[1]/archdesc[1]/@audience",
+		"warning: unsupported element preserved as raw element: /ead[1]/archdesc[1]/scopecontent[1]/head01[1]",
+	} {
+		if !strings.Contains(stderr, want) {
+			t.Errorf("Expected %q in stderr, got:\n%s", want, stderr)
+		}
+	}
+
+	code, _, stderr = runEADTool("ijson", validEADPath, invalidEADPath)
+	assertExitCode(t, exitUsage, code, stderr)
+
+	outputDir := t.TempDir()
+	code, _, stderr = runEADTool("ijson", "-o", outputDir, validEADPath, invalidEADPath)
+	assertExitCode(t, exitOK, code, stderr)
+	for _, path := range []string{validEADPath, invalidEADPath} {
+		if _, err := os.Stat(outputPath(outputDir, path, ".json")); err != nil {
+			t.Error(err)
+		}
+	}
+
+	code, _, stderr = runEADTool("ijson", filepath.Join(validateFixturesDir, "invalid-xml.xml"))
+	assertExitCode(t, exitFailure, code, stderr)
+}
+
+func TestFABifyCommand(t *testing.T) {
+	code, stdout, stderr := runEADTool("fabify", filepath.Join(modifyFixturesDir, "modify-input.xml"))
+	assertExitCode(t, exitOK, code, stderr)
+
+	want, err := os.ReadFile(filepath.Join(modifyFixturesDir, "modify-expected.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(want) != stdout {
+		t.Errorf("FABified EAD does not match %s", filepath.Join(modifyFixturesDir, "modify-expected.xml"))
+	}
+
+	code, _, stderr = runEADTool("fabify", filepath.Join(validateFixturesDir, "invalid-xml.xml"))
+	assertExitCode(t, exitFailure, code, stderr)
+}
+
+func TestComponentsCommand(t *testing.T) {
+	unnumberedPath := filepath.Join(modifyFixturesDir, "components-unnumbered.xml")
+	numberedPath := filepath.Join(modifyFixturesDir, "components-numbered.xml")
+	for _, tc := range []struct {
+		args          []string
+		referencePath string
+	}{
+		{[]string{"components", "-numbered", unnumberedPath}, numberedPath},
+		{[]string{"components", numberedPath}, unnumberedPath},
+	} {
+		code, stdout, stderr := runEADTool(tc.args...)
+		assertExitCode(t, exitOK, code, stderr)
+
+		want, err := os.ReadFile(tc.referencePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(want) != stdout {
+			t.Errorf("%v: converted EAD does not match %s", tc.args, tc.referencePath)
+		}
+	}
+
+	code, _, stderr := runEADTool("components", unnumberedPath, numberedPath)
+	assertExitCode(t, exitUsage, code, stderr)
+
+	code, _, stderr = runEADTool("components", filepath.Join(validateFixturesDir, "invalid-xml.xml"))
+	assertExitCode(t, exitFailure, code, stderr)
+}
+
+func TestStatsCommand(t *testing.T) {
+	omegaEADPath := filepath.Join("..", "..", "ead", "testdata", "omega", "v0.1.5", "Omega-EAD.xml")
+	code, stdout, stderr := runEADTool("stats", "-json", omegaEADPath)
+	assertExitCode(t, exitOK, code, stderr)
+
+	var got []EADStats
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("Unable to unmarshal JSON output: %s\n%s", err, stdout)
+	}
+	if len(got) != 1 {
+		t.Fatalf("Expected 1 result, got %d", len(got))
+	}
+
+	stats := got[0]
+	assertEqualInt(t, 14, int(stats.DAOCount), "DAOCount")
+	assertEqualInt(t, 3, int(stats.AudioDAOCount), "AudioDAOCount")
+	assertEqualInt(t, 2, int(stats.VideoDAOCount), "VideoDAOCount")
+	assertEqualInt(t, 4, int(stats.ImageDAOCount), "ImageDAOCount")
+	assertEqualInt(t, 2, int(stats.ExternalLinkDAOCount), "ExternalLinkDAOCount")
+
+	code, stdout, stderr = runEADTool("stats", omegaEADPath)
+	assertExitCode(t, exitOK, code, stderr)
+	if !strings.Contains(stdout, "EADID:") || !strings.Contains(stdout, stats.EADID) {
+		t.Errorf("Unexpected output:\n%s", stdout)
+	}
+}
+
+func assertEqualInt(t *testing.T, want int, got int, label string) {
+	if want != got {
+		t.Errorf("%s: expected %d, got %d", label, want, got)
+	}
+}
+
+func TestPullListCommand(t *testing.T) {
+	omegaEADPath := filepath.Join("..", "..", "ead", "testdata", "omega", "v0.1.5", "Omega-EAD.xml")
+	code, stdout, stderr := runEADTool("pulllist", omegaEADPath)
+	assertExitCode(t, exitOK, code, stderr)
+
+	referenceFile := filepath.Join("..", "..", "ead", "testdata", "inventory", "mos_2021-pull-list.csv")
+	want, err := os.ReadFile(referenceFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(want) != stdout {
+		t.Errorf("Pull list does not match %s", referenceFile)
+	}
+
+	code, stdout, stderr = runEADTool("pulllist", "-json", omegaEADPath)
+	assertExitCode(t, exitOK, code, stderr)
+
+	var got []map[string]string
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("Unable to unmarshal JSON output: %s\n%s", err, stdout)
+	}
+	assertEqualInt(t, 6, len(got), "Pull list entries")
+
+	code, _, stderr = runEADTool("pulllist", filepath.Join(validateFixturesDir, "invalid-xml.xml"))
+	assertExitCode(t, exitFailure, code, stderr)
+}
+
+func TestCoverageCommand(t *testing.T) {
+	omegaEADPath := filepath.Join("..", "..", "ead", "testdata", "omega", "v0.1.5", "Omega-EAD.xml")
+	blockElementsEADPath := filepath.Join("..", "..", "ead", "testdata", "block-elements", "block-elements.xml")
+	code, stdout, stderr := runEADTool("coverage", "-json", omegaEADPath, blockElementsEADPath)
+	assertExitCode(t, exitOK, code, stderr)
+
+	var got ead.Coverage
+	if err := json.Unmarshal([]byte(stdout), &got); err != nil {
+		t.Fatalf("Unable to unmarshal JSON output: %s\n%s", err, stdout)
+	}
+	assertEqualInt(t, 2, got.Files, "Files")
+	found := false
+	for _, item := range got.Items {
+		if item.Path == "eadheader/@findaidstatus" {
+			found = true
+			assertEqualInt(t, 2, item.Count, "eadheader/@findaidstatus Count")
+			assertEqualInt(t, 2, item.Files, "eadheader/@findaidstatus Files")
+		}
+	}
+	if !found {
+		t.Errorf("Expected eadheader/@findaidstatus in the coverage report, got:\n%s", stdout)
+	}
+
+	code, stdout, stderr = runEADTool("coverage", omegaEADPath)
+	assertExitCode(t, exitOK, code, stderr)
+	if !strings.Contains(stdout, "EADs: 1") || !strings.Contains(stdout, "c/bibliography") {
+		t.Errorf("Unexpected output:\n%s", stdout)
+	}
+
+	code, _, stderr = runEADTool("coverage", filepath.Join(validateFixturesDir, "invalid-xml.xml"))
+	assertExitCode(t, exitFailure, code, stderr)
+}
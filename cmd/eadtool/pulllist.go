@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/nyulibraries/dlts-finding-aids-ead-go-packages/ead"
+)
+
+func runPullList(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("pulllist", flag.ContinueOnError)
+	jsonOutput := flags.Bool("json", false, "print the pull list as JSON instead of CSV")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: eadtool pulllist [-json] PATH...")
+		flags.PrintDefaults()
+	}
+
+	files, code := parseFlagsAndInputs(flags, args, stderr)
+	if code >= 0 {
+		return code
+	}
+
+	exitCode := exitOK
+	var entries []ead.PullListEntry
+	for _, file := range files {
+		EADXML, err := os.ReadFile(file)
+		if err != nil {
+			fmt.Fprintf(stderr, "eadtool pulllist: %s: %s\n", file, err)
+			exitCode = exitFailure
+			continue
+		}
+
+		var sut ead.EAD
+		err = xml.Unmarshal(EADXML, &sut)
+		if err != nil {
+			fmt.Fprintf(stderr, "eadtool pulllist: %s: %s\n", file, err)
+			exitCode = exitFailure
+			continue
+		}
+
+		entries = append(entries, sut.PullList()...)
+	}
+
+	var err error
+	if *jsonOutput {
+		err = ead.WritePullListJSON(stdout, entries)
+	} else {
+		err = ead.WritePullListCSV(stdout, entries)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "eadtool pulllist: %s\n", err)
+		return exitFailure
+	}
+
+	return exitCode
+}
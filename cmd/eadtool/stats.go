@@ -0,0 +1,139 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/nyulibraries/dlts-finding-aids-ead-go-packages/ead"
+)
+
+// EADStats summarizes the component hierarchy and digital objects of an EAD
+type EADStats struct {
+	SourceFile                        string `json:"sourcefile"`
+	EADID                             string `json:"eadid"`
+	ComponentCount                    int    `json:"component_count"`
+	TopLevelComponentCount            int    `json:"top_level_component_count"`
+	MaximumComponentDepth             int    `json:"maximum_component_depth"`
+	DAOCount                          uint32 `json:"dao_count"`
+	AudioDAOCount                     uint32 `json:"audio_dao_count"`
+	VideoDAOCount                     uint32 `json:"video_dao_count"`
+	ImageDAOCount                     uint32 `json:"image_dao_count"`
+	ExternalLinkDAOCount              uint32 `json:"external_link_dao_count"`
+	ElectronicRecordsReadingRoomCount uint32 `json:"electronic_records_reading_room_dao_count"`
+	AudioReadingRoomDAOCount          uint32 `json:"audio_reading_room_dao_count"`
+	VideoReadingRoomDAOCount          uint32 `json:"video_reading_room_dao_count"`
+	DAOGrpCount                       uint32 `json:"daogrp_count"`
+}
+
+func runStats(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("stats", flag.ContinueOnError)
+	jsonOutput := flags.Bool("json", false, "print the statistics as JSON")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: eadtool stats [-json] PATH...")
+		flags.PrintDefaults()
+	}
+
+	files, code := parseFlagsAndInputs(flags, args, stderr)
+	if code >= 0 {
+		return code
+	}
+
+	exitCode := exitOK
+	allStats := []*EADStats{}
+	for _, file := range files {
+		stats, err := computeStats(file)
+		if err != nil {
+			fmt.Fprintf(stderr, "eadtool stats: %s: %s\n", file, err)
+			exitCode = exitFailure
+			continue
+		}
+
+		if *jsonOutput {
+			allStats = append(allStats, stats)
+		} else {
+			printStats(stdout, stats)
+		}
+	}
+
+	if *jsonOutput {
+		if err := writeJSON(stdout, allStats); err != nil {
+			fmt.Fprintf(stderr, "eadtool stats: %s\n", err)
+			return exitFailure
+		}
+	}
+
+	return exitCode
+}
+
+// computeStats streams the EAD so that very large finding aids can be
+// processed with bounded memory
+func computeStats(file string) (*EADStats, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	stats := &EADStats{SourceFile: file}
+
+	sut, err := ead.StreamEAD(f, ead.StreamHandler{
+		ArchDesc: func(e *ead.EAD) error {
+			ead.CountDIDDAOs(&e.ArchDesc.DID, &e.DAOInfo)
+			ead.CountDAOGrps(e.ArchDesc.DID.DAOGrp, &e.DAOGrpInfo)
+			return nil
+		},
+		C: func(e *ead.EAD, c *ead.C, index int) error {
+			cs := []*ead.C{c}
+			ead.CountCsDAOs(cs, &e.DAOInfo)
+			ead.CountCsDAOGrps(cs, &e.DAOGrpInfo)
+
+			stats.TopLevelComponentCount += 1
+			return ead.WalkCs(cs, func(c *ead.C, info *ead.WalkInfo) error {
+				stats.ComponentCount += 1
+				if info.Depth > stats.MaximumComponentDepth {
+					stats.MaximumComponentDepth = info.Depth
+				}
+				return nil
+			})
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if sut.ArchDesc == nil {
+		return nil, fmt.Errorf("missing <archdesc> element")
+	}
+
+	stats.EADID = sut.EADID()
+	stats.DAOCount = sut.AllDAOCount()
+	stats.AudioDAOCount = sut.AudioDAOCount()
+	stats.VideoDAOCount = sut.VideoDAOCount()
+	stats.ImageDAOCount = sut.ImageDAOCount()
+	stats.ExternalLinkDAOCount = sut.ExternalLinkDAOCount()
+	stats.ElectronicRecordsReadingRoomCount = sut.ElectronicRecordsReadingRoomDAOCount()
+	stats.AudioReadingRoomDAOCount = sut.AudioReadingRoomDAOCount()
+	stats.VideoReadingRoomDAOCount = sut.VideoReadingRoomDAOCount()
+	stats.DAOGrpCount = sut.AllDAOGrpCount()
+
+	return stats, nil
+}
+
+func printStats(w io.Writer, stats *EADStats) {
+	fmt.Fprintf(w, "%s:\n", stats.SourceFile)
+	fmt.Fprintf(w, "  %-38s %s\n", "EADID:", stats.EADID)
+	fmt.Fprintf(w, "  %-38s %d\n", "Components:", stats.ComponentCount)
+	fmt.Fprintf(w, "  %-38s %d\n", "Top-level components:", stats.TopLevelComponentCount)
+	fmt.Fprintf(w, "  %-38s %d\n", "Maximum component depth:", stats.MaximumComponentDepth)
+	fmt.Fprintf(w, "  %-38s %d\n", "DAOs:", stats.DAOCount)
+	fmt.Fprintf(w, "  %-38s %d\n", "Audio DAOs:", stats.AudioDAOCount)
+	fmt.Fprintf(w, "  %-38s %d\n", "Video DAOs:", stats.VideoDAOCount)
+	fmt.Fprintf(w, "  %-38s %d\n", "Image DAOs:", stats.ImageDAOCount)
+	fmt.Fprintf(w, "  %-38s %d\n", "External link DAOs:", stats.ExternalLinkDAOCount)
+	fmt.Fprintf(w, "  %-38s %d\n", "Electronic records reading room DAOs:", stats.ElectronicRecordsReadingRoomCount)
+	fmt.Fprintf(w, "  %-38s %d\n", "Audio reading room DAOs:", stats.AudioReadingRoomDAOCount)
+	fmt.Fprintf(w, "  %-38s %d\n", "Video reading room DAOs:", stats.VideoReadingRoomDAOCount)
+	fmt.Fprintf(w, "  %-38s %d\n", "DAO groups:", stats.DAOGrpCount)
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/nyulibraries/dlts-finding-aids-ead-go-packages/ead/validate"
+)
+
+func runValidate(args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	jsonOutput := flags.Bool("json", false, "print the validation results as JSON")
+	profilePath := flags.String("profile", "", "YAML or JSON validation profile `file`")
+	workers := flags.Int("workers", 0, "number of EADs to validate concurrently (default: number of CPUs)")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: eadtool validate [-json] [-profile FILE] [-workers N] PATH...")
+		flags.PrintDefaults()
+	}
+
+	files, code := parseFlagsAndInputs(flags, args, stderr)
+	if code >= 0 {
+		return code
+	}
+
+	validator := validate.NewDefaultValidator()
+	if *profilePath != "" {
+		profile, err := validate.ReadProfileFromFile(*profilePath)
+		if err != nil {
+			fmt.Fprintf(stderr, "eadtool validate: %s\n", err)
+			return exitUsage
+		}
+		validator, err = profile.NewValidator()
+		if err != nil {
+			fmt.Fprintf(stderr, "eadtool validate: %s\n", err)
+			return exitUsage
+		}
+	}
+
+	batchResults, err := validator.ValidateBatch(context.Background(), files, *workers)
+	if err != nil {
+		fmt.Fprintf(stderr, "eadtool validate: %s\n", err)
+		return exitFailure
+	}
+
+	// results arrive in completion order: collect them so that the
+	// output is in the same order as the files
+	resultsByPath := map[string]validate.BatchResult{}
+	for r := range batchResults {
+		resultsByPath[r.Path] = r
+	}
+
+	exitCode := exitOK
+	results := []*validate.ValidationResult{}
+	for _, file := range files {
+		r := resultsByPath[file]
+		if r.Err != nil {
+			fmt.Fprintf(stderr, "eadtool validate: %s: %s\n", file, r.Err)
+			exitCode = exitFailure
+			continue
+		}
+
+		if !r.Result.Valid() {
+			exitCode = exitFailure
+		}
+
+		if *jsonOutput {
+			results = append(results, r.Result)
+		} else {
+			printValidationResult(stdout, r.Result)
+		}
+	}
+
+	if *jsonOutput {
+		if err := writeJSON(stdout, results); err != nil {
+			fmt.Fprintf(stderr, "eadtool validate: %s\n", err)
+			return exitFailure
+		}
+	}
+
+	return exitCode
+}
+
+func printValidationResult(w io.Writer, result *validate.ValidationResult) {
+	if result.Valid() {
+		fmt.Fprintf(w, "%s: OK\n", result.Source)
+		return
+	}
+
+	fmt.Fprintf(w, "%s: INVALID\n", result.Source)
+	for _, issue := range result.Issues {
+		location := issue.XPath
+		if issue.Line > 0 {
+			location = fmt.Sprintf("%s (line %d, column %d)", location, issue.Line, issue.Column)
+		}
+		if location != "" {
+			fmt.Fprintf(w, "  [%s] %s %s\n", issue.Severity, issue.Code, location)
+		} else {
+			fmt.Fprintf(w, "  [%s] %s\n", issue.Severity, issue.Code)
+		}
+		for _, line := range strings.Split(strings.TrimSpace(issue.Message), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" {
+				fmt.Fprintln(w)
+				continue
+			}
+			fmt.Fprintf(w, "    %s\n", line)
+		}
+	}
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	jsonData, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", jsonData)
+	return err
+}
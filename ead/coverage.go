@@ -0,0 +1,125 @@
+package ead
+
+import (
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// CoverageItem is an element or attribute that does not make it into the
+// data model as-is, aggregated by its parent element
+type CoverageItem struct {
+	Kind WarningKind `json:"kind"`
+
+	// Path is the parent element name and the element or attribute name,
+	// e.g., "c/@audience" or "physdesc/genreform"
+	Path string `json:"path"`
+
+	// Count is the number of occurrences
+	Count int `json:"count"`
+
+	// Files is the number of EADs with at least one occurrence
+	Files int `json:"files"`
+}
+
+// Coverage reports the elements and attributes of one or more EADs that are
+// not part of the data model: the content that xml.Unmarshal drops and that
+// never reaches the iJSON, and the content that is only kept in the rendered
+// HTML of an iJSON "value", see FlattenedElement.  The items are sorted by
+// descending Count, then by Path.
+type Coverage struct {
+	Files int             `json:"files"`
+	Items []*CoverageItem `json:"items"`
+
+	// Renderer is the rendering of the "value" HTML: the elements and
+	// attributes that it renders are not reported as flattened.  A nil
+	// Renderer is the default rendering, NewRenderer().
+	Renderer *Renderer `json:"-"`
+}
+
+// AnalyzeCoverage returns the Coverage of the EAD read from r
+func AnalyzeCoverage(r io.Reader) (*Coverage, error) {
+	coverage := &Coverage{Items: []*CoverageItem{}}
+	err := coverage.Add(r)
+	if err != nil {
+		return nil, err
+	}
+	return coverage, nil
+}
+
+// Add compares the XML token stream of the EAD read from r against the data
+// model, and adds the elements and attributes that are lost to the Coverage.
+// The Coverage is not changed if the XML is not well-formed.
+func (coverage *Coverage) Add(r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	renderer := coverage.Renderer
+	if renderer == nil {
+		renderer = defaultRenderer
+	}
+	warnings, err := getWarnings(data, renderer)
+	if err != nil {
+		return err
+	}
+
+	items := map[WarningKind]map[string]*CoverageItem{}
+	for _, item := range coverage.Items {
+		if items[item.Kind] == nil {
+			items[item.Kind] = map[string]*CoverageItem{}
+		}
+		items[item.Kind][item.Path] = item
+	}
+
+	seen := map[*CoverageItem]bool{}
+	for _, warning := range warnings {
+		path := coveragePath(warning.Path)
+		if items[warning.Kind] == nil {
+			items[warning.Kind] = map[string]*CoverageItem{}
+		}
+
+		item := items[warning.Kind][path]
+		if item == nil {
+			item = &CoverageItem{Kind: warning.Kind, Path: path}
+			items[warning.Kind][path] = item
+			coverage.Items = append(coverage.Items, item)
+		}
+
+		item.Count++
+		if !seen[item] {
+			seen[item] = true
+			item.Files++
+		}
+	}
+
+	coverage.Files++
+
+	sort.SliceStable(coverage.Items, func(i, j int) bool {
+		a, b := coverage.Items[i], coverage.Items[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Kind < b.Kind
+	})
+
+	return nil
+}
+
+var pathPositionRegexp = regexp.MustCompile(`\[\d+\]`)
+
+// coveragePath returns the last two steps of a Warning path without the
+// positions, e.g., "c/@audience" for
+// "/ead[1]/archdesc[1]/dsc[1]/c[2]/c[1]/@audience"
+func coveragePath(path string) string {
+	steps := strings.Split(pathPositionRegexp.ReplaceAllString(path, ""), "/")
+	if len(steps) > 2 {
+		steps = steps[len(steps)-2:]
+	}
+	return strings.Join(steps, "/")
+}
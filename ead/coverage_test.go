@@ -0,0 +1,99 @@
+package ead
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func coverageItemsString(coverage *Coverage) string {
+	var lines []string
+	for _, item := range coverage.Items {
+		lines = append(lines, fmt.Sprintf("%d %d %s: %s", item.Count, item.Files, item.Kind, item.Path))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestAnalyzeCoverage(t *testing.T) {
+	t.Run("Lost Elements and Attributes", func(t *testing.T) {
+		EADXML := `<ead><archdesc level="collection"><did><unitid repositorycode="NNU">1</unitid></did><dsc>` +
+			`<c audience="internal"><did><unittitle>Letters<lb/><emph render="bold">1920</emph></unittitle>` +
+			`<physdesc><extent>1 folder</extent><genreform source="aat">Photographs in <emph render="italic" altrender="color">color</emph></genreform></physdesc>` +
+			`<dao xlink:href="https://example.org/1" xlink:label="one" xmlns:xlink="http://www.w3.org/1999/xlink"/></did>` +
+			`<c audience="internal"><did><physdesc><genreform>Maps</genreform></physdesc></did></c></c>` +
+			`</dsc></archdesc></ead>`
+
+		sut, err := AnalyzeCoverage(strings.NewReader(EADXML))
+		failOnError(t, err, "Unexpected error analyzing coverage")
+
+		want := strings.Join([]string{
+			"2 1 skipped attribute: c/@audience",
+			"2 1 element flattened into inline content: physdesc/genreform",
+			"1 1 skipped attribute: dao/@xlink:label",
+			"1 1 attribute of flattened element: emph/@altrender",
+			"1 1 attribute of flattened element: genreform/@source",
+			"1 1 skipped attribute: unitid/@repositorycode",
+		}, "\n")
+		assertEqual(t, want, coverageItemsString(sut), "Coverage Items")
+		assertEqual(t, "1", fmt.Sprint(sut.Files), "Coverage Files")
+	})
+
+	t.Run("Renderer", func(t *testing.T) {
+		EADXML := `<ead><archdesc level="collection"><did><physdesc><extent>1 folder</extent>` +
+			`<genreform source="aat">Photographs</genreform></physdesc></did></archdesc></ead>`
+
+		renderer := NewRenderer()
+		renderer.Elements["genreform"] = ElementRendering{Tag: "span", Attr: map[string]string{"source": "data-source"}}
+		sut := &Coverage{Renderer: renderer}
+		err := sut.Add(strings.NewReader(EADXML))
+		failOnError(t, err, "Unexpected error analyzing coverage")
+		assertEqual(t, "", coverageItemsString(sut), "Coverage Items with a Renderer of <genreform>")
+
+		sut, err = AnalyzeCoverage(strings.NewReader(EADXML))
+		failOnError(t, err, "Unexpected error analyzing coverage")
+		want := strings.Join([]string{
+			"1 1 attribute of flattened element: genreform/@source",
+			"1 1 element flattened into inline content: physdesc/genreform",
+		}, "\n")
+		assertEqual(t, want, coverageItemsString(sut), "Coverage Items with the default Renderer")
+	})
+
+	t.Run("Corpus", func(t *testing.T) {
+		sut := &Coverage{}
+		for _, path := range []string{
+			filepath.Join(blockElementsTestFixturePath, "block-elements.xml"),
+			omegaTestFixturePath + "/Omega-EAD.xml",
+		} {
+			f, err := os.Open(path)
+			failOnError(t, err, "Unexpected error opening EAD")
+			err = sut.Add(f)
+			f.Close()
+			failOnError(t, err, "Unexpected error analyzing coverage")
+		}
+
+		assertEqual(t, "2", fmt.Sprint(sut.Files), "Coverage Files")
+		assertEqual(t, "genreform/@source", sut.Items[0].Path, "First Coverage Item Path")
+		assertEqual(t, "27", fmt.Sprint(sut.Items[0].Count), "First Coverage Item Count")
+		for _, item := range sut.Items {
+			if item.Path == "eadheader/@findaidstatus" {
+				assertEqual(t, "2", fmt.Sprint(item.Files), "eadheader/@findaidstatus Files")
+			}
+		}
+	})
+
+	t.Run("Not Well-Formed", func(t *testing.T) {
+		sut := &Coverage{}
+		err := sut.Add(strings.NewReader(`<ead><archdesc audience="internal">`))
+		if err == nil {
+			t.Errorf("Expected an error analyzing XML that is not well-formed")
+		}
+		err = sut.Add(strings.NewReader("This is not XML!"))
+		if err == nil {
+			t.Errorf("Expected an error analyzing text that is not XML")
+		}
+		assertEqual(t, "0", fmt.Sprint(sut.Files), "Coverage Files")
+		assertEqual(t, "", coverageItemsString(sut), "Coverage Items")
+	})
+}
@@ -0,0 +1,209 @@
+package ead
+
+// DateSummary stores the dates aggregated from the <unitdate> elements of
+// the <archdesc> <did> and all components, for use in timelines.
+//
+// CollectionBegin and CollectionEnd are the collection's stated date range,
+// i.e., the range of the inclusive (non-bulk) <archdesc> <did> <unitdate>
+// elements.  If there are only bulk dates, they are used instead.
+//
+// Earliest and Latest are the earliest and latest dates found anywhere in
+// the EAD.
+type DateSummary struct {
+	CollectionBegin string                `json:"collectionbegin,omitempty"`
+	CollectionEnd   string                `json:"collectionend,omitempty"`
+	Earliest        string                `json:"earliest,omitempty"`
+	Latest          string                `json:"latest,omitempty"`
+	Decades         []DecadeCount         `json:"decades,omitempty"`
+	OutOfRange      []*ComponentDateRange `json:"outofrange,omitempty"`
+	UndatedCount    uint32                `json:"undatedcount"`
+	Components      []*ComponentDateRange `json:"-"`
+}
+
+// DecadeCount is a decade histogram bucket: Count is the number of
+// components with a date in the decade starting with year Decade
+type DecadeCount struct {
+	Decade int    `json:"decade"`
+	Count  uint32 `json:"count"`
+}
+
+// ComponentDateRange is the date range of a component, combined from all of
+// the component's <did> <unitdate> elements
+type ComponentDateRange struct {
+	ID    string `json:"id"`
+	Title string `json:"title,omitempty"`
+	Begin string `json:"begin"`
+	End   string `json:"end"`
+	C     *C     `json:"-"`
+}
+
+// InitDateSummary aggregates the <unitdate> elements of the <archdesc> <did>
+// and all components into e.DateSummary, which is included in the iJSON
+func (e *EAD) InitDateSummary() error {
+	e.DateSummary = nil
+	if e.ArchDesc == nil {
+		return nil
+	}
+
+	var dateSummary DateSummary
+
+	collectionBegin, collectionEnd := collectionDateRange(e.ArchDesc.DID.UnitDate)
+	dateSummary.CollectionBegin = collectionBegin
+	dateSummary.CollectionEnd = collectionEnd
+	dateSummary.Earliest, dateSummary.Latest, _ = combinedDateRange(e.ArchDesc.DID.UnitDate, false)
+
+	err := e.WalkComponents(func(c *C, info *WalkInfo) error {
+		return summarizeCDates(c, &dateSummary)
+	})
+	if err != nil {
+		return err
+	}
+
+	dateSummary.Decades = decadeHistogram(dateSummary.Components)
+
+	if collectionBegin != "" {
+		for _, cdr := range dateSummary.Components {
+			if beginSortKey(cdr.Begin) < beginSortKey(collectionBegin) ||
+				endSortKey(cdr.End) > endSortKey(collectionEnd) {
+				dateSummary.OutOfRange = append(dateSummary.OutOfRange, cdr)
+			}
+		}
+	}
+
+	e.DateSummary = &dateSummary
+
+	return nil
+}
+
+// process a component
+func summarizeCDates(c *C, dateSummary *DateSummary) error {
+	begin, end, undated := combinedDateRange(c.DID.UnitDate, false)
+	if begin != "" {
+		var title []byte
+		if c.DID.UnitTitle != nil {
+			var err error
+			title, err = c.DID.UnitTitle.htmlRenderer().convertTextWithTags(c.DID.UnitTitle.Value)
+			if err != nil {
+				return err
+			}
+		}
+
+		dateSummary.Components = append(dateSummary.Components, &ComponentDateRange{
+			ID:    string(c.ID),
+			Title: string(title),
+			Begin: begin,
+			End:   end,
+			C:     c,
+		})
+
+		if dateSummary.Earliest == "" || beginSortKey(begin) < beginSortKey(dateSummary.Earliest) {
+			dateSummary.Earliest = begin
+		}
+		if dateSummary.Latest == "" || endSortKey(end) > endSortKey(dateSummary.Latest) {
+			dateSummary.Latest = end
+		}
+	} else if undated {
+		dateSummary.UndatedCount += 1
+	}
+
+	return nil
+}
+
+// collectionDateRange returns the range of the inclusive <unitdate> elements,
+// falling back to the bulk dates
+func collectionDateRange(unitDates []*UnitDate) (string, string) {
+	begin, end, _ := combinedDateRange(unitDates, true)
+	if begin == "" {
+		begin, end, _ = combinedDateRange(unitDates, false)
+	}
+	return begin, end
+}
+
+// combinedDateRange returns the earliest begin date and latest end date of
+// the <unitdate> elements, and whether any of them are marked as undated
+func combinedDateRange(unitDates []*UnitDate, skipBulk bool) (string, string, bool) {
+	var begin, end string
+	var undated bool
+
+	for _, unitDate := range unitDates {
+		dateRange := unitDate.DateRange()
+		if dateRange == nil || (skipBulk && dateRange.Bulk) {
+			continue
+		}
+		if dateRange.Undated {
+			undated = true
+		}
+		if !dateRange.IsDated() {
+			continue
+		}
+		if begin == "" || beginSortKey(dateRange.Begin) < beginSortKey(begin) {
+			begin = dateRange.Begin
+		}
+		if end == "" || endSortKey(dateRange.End) > endSortKey(end) {
+			end = dateRange.End
+		}
+	}
+
+	return begin, end, undated
+}
+
+// decadeHistogram counts the components with dates in each decade.  All
+// decades between the earliest and latest decade are included.
+func decadeHistogram(components []*ComponentDateRange) []DecadeCount {
+	counts := map[int]uint32{}
+	first, last := 0, 0
+
+	for i, cdr := range components {
+		beginDecade := dateYear(cdr.Begin) / 10 * 10
+		endDecade := dateYear(cdr.End) / 10 * 10
+		for decade := beginDecade; decade <= endDecade; decade += 10 {
+			counts[decade] += 1
+		}
+		if i == 0 || beginDecade < first {
+			first = beginDecade
+		}
+		if i == 0 || endDecade > last {
+			last = endDecade
+		}
+	}
+
+	if len(counts) == 0 {
+		return nil
+	}
+
+	var decades []DecadeCount
+	for decade := first; decade <= last; decade += 10 {
+		decades = append(decades, DecadeCount{Decade: decade, Count: counts[decade]})
+	}
+	return decades
+}
+
+func (e *EAD) EarliestDate() string {
+	if e.DateSummary == nil {
+		return ""
+	}
+	return e.DateSummary.Earliest
+}
+
+func (e *EAD) LatestDate() string {
+	if e.DateSummary == nil {
+		return ""
+	}
+	return e.DateSummary.Latest
+}
+
+func (e *EAD) DecadeHistogram() []DecadeCount {
+	if e.DateSummary == nil {
+		return nil
+	}
+	return e.DateSummary.Decades
+}
+
+// ComponentsOutsideCollectionDateRange returns the components with dates
+// outside of the collection's stated date range
+func (e *EAD) ComponentsOutsideCollectionDateRange() []*ComponentDateRange {
+	if e.DateSummary == nil {
+		return nil
+	}
+	return e.DateSummary.OutOfRange
+}
@@ -0,0 +1,117 @@
+package ead
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func formatDecadeHistogram(decades []DecadeCount) string {
+	var s []string
+	for _, d := range decades {
+		s = append(s, fmt.Sprintf("%d:%d", d.Decade, d.Count))
+	}
+	return strings.Join(s, " ")
+}
+
+func TestInitDateSummary(t *testing.T) {
+	t.Run("InitDateSummary", func(t *testing.T) {
+		sut := getTestEAD(t, filepath.Join(tamwagTestFixturePath, "mos_2021-with-test-unitdates.xml"))
+		err := sut.InitDateSummary()
+		failOnError(t, err, "Unexpected error initializing date summary")
+
+		assertEqual(t, "1910", sut.DateSummary.CollectionBegin, "CollectionBegin")
+		assertEqual(t, "2021", sut.DateSummary.CollectionEnd, "CollectionEnd")
+		assertEqual(t, "1910", sut.EarliestDate(), "EarliestDate()")
+		assertEqual(t, "2021", sut.LatestDate(), "LatestDate()")
+		assertEqual(t, "1980:2 1990:0 2000:0 2010:3 2020:2", formatDecadeHistogram(sut.DecadeHistogram()), "DecadeHistogram()")
+		if len(sut.ComponentsOutsideCollectionDateRange()) != 0 {
+			t.Errorf("Expected no components outside the collection date range")
+		}
+	})
+}
+
+func TestInitDateSummaryOutOfRange(t *testing.T) {
+	t.Run("InitDateSummary Out of Range", func(t *testing.T) {
+		sut := getTestEAD(t, filepath.Join(testFixturePath, "fales", "mss_460.xml"))
+		err := sut.InitDateSummary()
+		failOnError(t, err, "Unexpected error initializing date summary")
+
+		assertEqual(t, "2015", sut.DateSummary.CollectionBegin, "CollectionBegin")
+		assertEqual(t, "2016", sut.DateSummary.CollectionEnd, "CollectionEnd")
+		assertEqual(t, "2017", sut.LatestDate(), "LatestDate()")
+
+		got := sut.ComponentsOutsideCollectionDateRange()
+		if len(got) != 1 {
+			t.Fatalf("Expected 1 component outside the collection date range, got %d", len(got))
+		}
+		assertEqual(t, "aspace_de1b3418920e96ca20bfb61a85122d97", got[0].ID, "Out of range component ID")
+		assertEqual(t, "Archived website", got[0].Title, "Out of range component title")
+		assertEqual(t, "2017", got[0].Begin, "Out of range component begin")
+		if got[0].C == nil || string(got[0].C.ID) != got[0].ID {
+			t.Errorf("Expected the out of range component to reference its <c>")
+		}
+	})
+}
+
+func TestInitDateSummaryBulkAndUndated(t *testing.T) {
+	t.Run("InitDateSummary Bulk and Undated", func(t *testing.T) {
+		sut := EAD{ArchDesc: &ArchDesc{
+			DID: DID{UnitDate: []*UnitDate{
+				{Value: "1900-1950", Type: "inclusive"},
+				{Value: "1920-1930", Type: "bulk"},
+			}},
+			DSC: &DSC{C: []*C{
+				{ID: "c1", DID: DID{UnitDate: []*UnitDate{{Value: "circa 1895"}}}, C: []*C{
+					{ID: "c1.1", DID: DID{UnitDate: []*UnitDate{{Value: "undated"}}}},
+					{ID: "c1.2", DID: DID{UnitDate: []*UnitDate{{Value: "1925"}, {Value: "1949 March"}}}},
+				}},
+				{ID: "c2"},
+			}},
+		}}
+
+		err := sut.InitDateSummary()
+		failOnError(t, err, "Unexpected error initializing date summary")
+
+		assertEqual(t, "1900", sut.DateSummary.CollectionBegin, "CollectionBegin ignores bulk dates")
+		assertEqual(t, "1950", sut.DateSummary.CollectionEnd, "CollectionEnd ignores bulk dates")
+		assertEqual(t, "1895", sut.EarliestDate(), "EarliestDate()")
+		assertEqual(t, "1950", sut.LatestDate(), "LatestDate()")
+		assertEqual(t, "1890:1 1900:0 1910:0 1920:1 1930:1 1940:1", formatDecadeHistogram(sut.DecadeHistogram()), "DecadeHistogram()")
+		assertEqualUint32(t, 1, sut.DateSummary.UndatedCount, "UndatedCount")
+
+		got := sut.ComponentsOutsideCollectionDateRange()
+		if len(got) != 1 || got[0].ID != "c1" {
+			t.Errorf("Expected component c1 to be outside the collection date range, got %v", got)
+		}
+
+		// bulk dates are used if there are no inclusive dates
+		sut.ArchDesc.DID.UnitDate = sut.ArchDesc.DID.UnitDate[1:]
+		err = sut.InitDateSummary()
+		failOnError(t, err, "Unexpected error initializing date summary")
+		assertEqual(t, "1920", sut.DateSummary.CollectionBegin, "CollectionBegin from bulk dates")
+		if len(sut.ComponentsOutsideCollectionDateRange()) != 2 {
+			t.Errorf("Expected 2 components outside the bulk date range, got %d", len(sut.ComponentsOutsideCollectionDateRange()))
+		}
+	})
+}
+
+func TestDateSummaryJSON(t *testing.T) {
+	t.Run("DateSummary JSON", func(t *testing.T) {
+		sut := getOmegaEAD(t)
+
+		jsonData, err := json.Marshal(sut)
+		failOnError(t, err, "Unexpected error marshaling JSON")
+		if strings.Contains(string(jsonData), `"datesummary"`) {
+			t.Errorf("Expected no datesummary before InitDateSummary() is called")
+		}
+
+		err = sut.InitDateSummary()
+		failOnError(t, err, "Unexpected error initializing date summary")
+		jsonData, err = json.Marshal(sut)
+		failOnError(t, err, "Unexpected error marshaling JSON")
+		assertContains(t, string(jsonData), `"datesummary":{"collectionbegin":"2016","collectionend":"2021","earliest":"1981-08-31","latest":"2021"`, "datesummary")
+	})
+}
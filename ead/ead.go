@@ -5,17 +5,21 @@ package ead
 // Based on: "Data model for parsing EAD <archdesc> elements": https://jira.nyu.edu/jira/browse/FADESIGN-29.
 
 const (
-	Version = "v0.31.0"
+	Version = "v0.57.0"
 )
 
 type EAD struct {
-	RunInfo    RunInfo    `json:"runinfo"`
-	DAOInfo    DAOInfo    `json:"-"`
-	DAOGrpInfo DAOGrpInfo `json:"-"`
-	PubInfo    PubInfo    `json:"pubinfo"`
-	Donors     Donors     `json:"donors,omitempty"`
-	ArchDesc   *ArchDesc  `xml:"archdesc" json:"archdesc,omitempty"`
-	EADHeader  EADHeader  `xml:"eadheader" json:"eadheader,omitempty"`
+	RunInfo     RunInfo      `json:"runinfo"`
+	DAOInfo     DAOInfo      `json:"-"`
+	DAOGrpInfo  DAOGrpInfo   `json:"-"`
+	PubInfo     PubInfo      `json:"pubinfo"`
+	Donors      Donors       `json:"donors,omitempty"`
+	DateSummary *DateSummary `json:"datesummary,omitempty"`
+	ArchDesc    *ArchDesc    `xml:"archdesc" json:"archdesc,omitempty"`
+	EADHeader   EADHeader    `xml:"eadheader" json:"eadheader,omitempty"`
+
+	// Warnings are recorded by lenient decoding, see Decode()
+	Warnings []Warning `xml:"-" json:"-"`
 }
 
 type Abstract struct {
@@ -24,12 +28,17 @@ type Abstract struct {
 	Title []*Title `xml:"title" json:"title,omitempty"`
 
 	Value string `xml:",innerxml" json:"value,omitempty"`
+
+	inlineValue
+	rendering
 }
 
 type AccessTermWithRole struct {
 	Role string `xml:"role,attr" json:"role,omitempty"`
 
 	Value string `xml:",innerxml" json:"value,omitempty"`
+
+	rendering
 }
 
 type Address struct {
@@ -40,6 +49,9 @@ type AddressLine struct {
 	ExtPtr []*ExtPtr `xml:"extptr" json:"extptr,omitempty"`
 
 	Value string `xml:",innerxml" json:"value,omitempty"`
+
+	inlineValue
+	rendering
 }
 
 type ArchDesc struct {
@@ -74,6 +86,9 @@ type ArchRef struct {
 	PhysLoc []*PhysLoc `xml:"physloc" json:"physloc,omitempty"`
 
 	Value string `xml:",innerxml" json:"value,omitempty"`
+
+	inlineValue
+	rendering
 }
 
 type Bibliography struct {
@@ -89,6 +104,15 @@ type BibRef struct {
 	Title []*Title `xml:"title" json:"title,omitempty"`
 
 	Value string `xml:",innerxml" json:"value,omitempty"`
+
+	inlineValue
+	rendering
+}
+
+type BlockQuote struct {
+	ID FilteredString `xml:"id,attr" json:"id,omitempty"`
+
+	Children []*EADChild `xml:",any" json:"children,omitempty"`
 }
 
 type C struct {
@@ -119,10 +143,15 @@ type C struct {
 	ScopeContent      []*FormattedNoteWithHead `xml:"scopecontent,omitempty" json:"scopecontent,omitempty"`
 	SeparatedMaterial []*FormattedNoteWithHead `xml:"separatedmaterial" json:"separatedmaterial,omitempty"`
 	UseRestrict       []*FormattedNoteWithHead `xml:"userestrict,omitempty" json:"userestrict,omitempty"`
+
+	Parent  *C     `xml:"-" json:"-"`
+	SortKey string `xml:"-" json:"sortkey,omitempty"`
 }
 
 type CDATA struct {
 	Value string `xml:",innerxml" json:"value,omitempty"`
+
+	rendering
 }
 
 type Change struct {
@@ -135,6 +164,9 @@ type ChronItem struct {
 	EventGrp []*EventGrp `xml:"eventgrp,omitempty" json:"eventgrp,omitempty"`
 
 	Value string `xml:",innerxml" json:"value,omitempty"`
+
+	inlineValue
+	rendering
 }
 
 type ChronList struct {
@@ -142,6 +174,17 @@ type ChronList struct {
 	ChronItem []*ChronItem `xml:"chronitem,omitempty" json:"chronitem,omitempty"`
 }
 
+type ColSpec struct {
+	Align    FilteredString `xml:"align,attr" json:"align,omitempty"`
+	Char     FilteredString `xml:"char,attr" json:"char,omitempty"`
+	CharOff  FilteredString `xml:"charoff,attr" json:"charoff,omitempty"`
+	ColName  FilteredString `xml:"colname,attr" json:"colname,omitempty"`
+	ColNum   FilteredString `xml:"colnum,attr" json:"colnum,omitempty"`
+	ColSep   FilteredString `xml:"colsep,attr" json:"colsep,omitempty"`
+	ColWidth FilteredString `xml:"colwidth,attr" json:"colwidth,omitempty"`
+	RowSep   FilteredString `xml:"rowsep,attr" json:"rowsep,omitempty"`
+}
+
 type Container struct {
 	AltRender FilteredString      `xml:"altrender,attr" json:"altrender,omitempty"`
 	ID        FilteredString      `xml:"id,attr" json:"id,omitempty"`
@@ -150,6 +193,13 @@ type Container struct {
 	Type      FilteredString      `xml:"type,attr" json:"type,omitempty"`
 
 	Value string `xml:",innerxml" json:"value,omitempty"`
+
+	// Barcode is parsed from the bracketed text of the @label when the
+	// <container> is unmarshaled, e.g., "Mixed Materials [31142042214224]"
+	Barcode string `xml:"-" json:"barcode,omitempty"`
+
+	inlineValue
+	rendering
 }
 
 type ControlAccess struct {
@@ -167,19 +217,22 @@ type ControlAccess struct {
 type Creation struct {
 	Date  []*Date `xml:"date" json:"date,omitempty"`
 	Value string  `xml:",innerxml" json:"value,omitempty"`
+
+	inlineValue
+	rendering
 }
 
 type DAO struct {
-	Actuate FilteredString `xml:"actuate,attr" json:"actuate,omitempty"`
-	Href    FilteredString `xml:"href,attr" json:"href,omitempty"`
-	Role    FilteredString `xml:"role,attr" json:"role,omitempty"`
-	Show    FilteredString `xml:"show,attr" json:"show,omitempty"`
-	DOType  FilteredString `json:"do_type,omitempty"`
-	Count   uint64         `json:"count,omitempty"`
-	Width   uint32         `json:"width,omitempty"`
-	Height  uint32         `json:"height,omitempty"`
-	Title   FilteredString `xml:"title,attr" json:"title,omitempty"`
-	Type    FilteredString `xml:"type,attr" json:"type,omitempty"`
+	Actuate FilteredString    `xml:"actuate,attr" json:"actuate,omitempty"`
+	Href    FilteredURLString `xml:"href,attr" json:"href,omitempty"`
+	Role    FilteredString    `xml:"role,attr" json:"role,omitempty"`
+	Show    FilteredString    `xml:"show,attr" json:"show,omitempty"`
+	DOType  FilteredString    `json:"do_type,omitempty"`
+	Count   uint64            `json:"count,omitempty"`
+	Width   uint32            `json:"width,omitempty"`
+	Height  uint32            `json:"height,omitempty"`
+	Title   FilteredString    `xml:"title,attr" json:"title,omitempty"`
+	Type    FilteredString    `xml:"type,attr" json:"type,omitempty"`
 
 	ParentDID *DID    `xml:"-" json:"-"`
 	DAODesc   DAODesc `xml:"daodesc" json:"daodesc,omitempty"`
@@ -198,16 +251,19 @@ type DAOGrp struct {
 }
 
 type DAOLoc struct {
-	Href  FilteredString `xml:"href,attr" json:"href,omitempty"`
-	Role  FilteredString `xml:"role,attr" json:"role,omitempty"`
-	Title FilteredString `xml:"title,attr" json:"title,omitempty"`
-	Type  FilteredString `xml:"type,attr" json:"type,omitempty"`
+	Href  FilteredURLString `xml:"href,attr" json:"href,omitempty"`
+	Role  FilteredString    `xml:"role,attr" json:"role,omitempty"`
+	Title FilteredString    `xml:"title,attr" json:"title,omitempty"`
+	Type  FilteredString    `xml:"type,attr" json:"type,omitempty"`
 }
 
 type Date struct {
 	Type FilteredString `xml:"type,attr" json:"type,omitempty"`
 
 	Value string `xml:",innerxml" json:"value,omitempty"`
+
+	inlineValue
+	rendering
 }
 
 type DefItem struct {
@@ -237,11 +293,15 @@ type Dimensions struct {
 	Label FilteredLabelString `xml:"label,attr" json:"label,omitempty"`
 
 	Value string `xml:",innerxml" json:"value,omitempty"`
+
+	inlineValue
+	rendering
 }
 
 type DSC struct {
-	C []*C `xml:"c,omitempty" json:"c,omitempty"`
-	P []*P `xml:"p,omitempty" json:"p,omitempty"`
+	C     []*C     `xml:"c,omitempty" json:"c,omitempty"`
+	P     []*P     `xml:"p,omitempty" json:"p,omitempty"`
+	Table []*Table `xml:"table,omitempty" json:"table,omitempty"`
 }
 
 type EADHeader struct {
@@ -265,10 +325,31 @@ type EditionStmt struct {
 	P []*P `xml:"p,omitempty" json:"p,omitempty"`
 }
 
+type Entry struct {
+	Align    FilteredString `xml:"align,attr" json:"align,omitempty"`
+	Char     FilteredString `xml:"char,attr" json:"char,omitempty"`
+	CharOff  FilteredString `xml:"charoff,attr" json:"charoff,omitempty"`
+	ColName  FilteredString `xml:"colname,attr" json:"colname,omitempty"`
+	ColSep   FilteredString `xml:"colsep,attr" json:"colsep,omitempty"`
+	MoreRows FilteredString `xml:"morerows,attr" json:"morerows,omitempty"`
+	NameEnd  FilteredString `xml:"nameend,attr" json:"nameend,omitempty"`
+	NameSt   FilteredString `xml:"namest,attr" json:"namest,omitempty"`
+	RowSep   FilteredString `xml:"rowsep,attr" json:"rowsep,omitempty"`
+	VAlign   FilteredString `xml:"valign,attr" json:"valign,omitempty"`
+
+	Value string `xml:",innerxml" json:"value,omitempty"`
+
+	inlineValue
+	rendering
+}
+
 type Event struct {
 	Title []*Title `xml:"title" json:"title,omitempty"`
 
 	Value string `xml:",innerxml" json:"value,omitempty"`
+
+	inlineValue
+	rendering
 }
 
 type EventGrp struct {
@@ -281,23 +362,25 @@ type Extent struct {
 	Unit FilteredString `xml:"unit,attr" json:"unit,omitempty"`
 
 	Value string `xml:",innerxml" json:"value,omitempty"`
+
+	rendering
 }
 
 type ExtPtr struct {
-	Href  FilteredString `xml:"href,attr" json:"href,omitempty"`
-	Show  FilteredString `xml:"show,attr" json:"show,omitempty"`
-	Title FilteredString `xml:"title,attr" json:"title,omitempty"`
-	Type  FilteredString `xml:"type,attr" json:"type,omitempty"`
+	Href  FilteredURLString `xml:"href,attr" json:"href,omitempty"`
+	Show  FilteredString    `xml:"show,attr" json:"show,omitempty"`
+	Title FilteredString    `xml:"title,attr" json:"title,omitempty"`
+	Type  FilteredString    `xml:"type,attr" json:"type,omitempty"`
 }
 
 type ExtRef struct {
-	Actuate    FilteredString `xml:"actuate,attr" json:"actuate,omitempty"`
-	Href       FilteredString `xml:"href,attr" json:"href,omitempty"`
-	Show       FilteredString `xml:"show,attr" json:"show,omitempty"`
-	Title      FilteredString `xml:"title,attr" json:"title,omitempty"`
-	Type       FilteredString `xml:"type,attr" json:"type,omitempty"`
-	ArchRef    []*ArchRef     `xml:"archref" json:"archref,omitempty"`
-	TitleValue []*Title       `xml:"title" json:"titlevalue,omitempty"`
+	Actuate    FilteredString    `xml:"actuate,attr" json:"actuate,omitempty"`
+	Href       FilteredURLString `xml:"href,attr" json:"href,omitempty"`
+	Show       FilteredString    `xml:"show,attr" json:"show,omitempty"`
+	Title      FilteredString    `xml:"title,attr" json:"title,omitempty"`
+	Type       FilteredString    `xml:"type,attr" json:"type,omitempty"`
+	ArchRef    []*ArchRef        `xml:"archref" json:"archref,omitempty"`
+	TitleValue []*Title          `xml:"title" json:"titlevalue,omitempty"`
 }
 
 type FileDesc struct {
@@ -326,10 +409,15 @@ type FormattedNoteWithHead struct {
 
 	// adding Don Mennerich's approach here...
 	Children []*EADChild `xml:",any" json:"children,omitempty"`
+
+	rendering
 }
 
 type Head struct {
 	Value string `xml:",innerxml" json:"value,omitempty"`
+
+	inlineValue
+	rendering
 }
 
 type Index struct {
@@ -358,6 +446,9 @@ type Item struct {
 	Title    []*Title              `xml:"title" json:"title,omitempty"`
 
 	Value string `xml:",innerxml" json:"value,omitempty"`
+
+	inlineValue
+	rendering
 }
 
 type LangMaterial struct {
@@ -366,18 +457,27 @@ type LangMaterial struct {
 	Language *[]FilteredString `xml:"language" json:"language,omitempty"`
 
 	Value string `xml:",innerxml" json:"value,omitempty"`
+
+	inlineValue
+	rendering
 }
 
 type LangUsage struct {
 	Language *[]FilteredString `xml:"language" json:"language,omitempty"`
 
 	Value string `xml:",innerxml" json:"value,omitempty"`
+
+	inlineValue
+	rendering
 }
 
 type LegalStatus struct {
 	ID FilteredString `xml:"id,attr" json:"id,omitempty"`
 
 	Value string `xml:",innerxml" json:"value,omitempty"`
+
+	inlineValue
+	rendering
 }
 
 type List struct {
@@ -391,6 +491,9 @@ type List struct {
 
 type Note struct {
 	P []*P `xml:"p" json:"p,omitempty"`
+
+	// the other block elements of a <note>, e.g., <list> and <table>
+	Children []*EADChild `xml:",any" json:"children,omitempty"`
 }
 
 type NoteStmt struct {
@@ -401,6 +504,9 @@ type Num struct {
 	Type FilteredString `xml:"type,attr" json:"type,omitempty"`
 
 	Value string `xml:",innerxml" json:"value,omitempty"`
+
+	inlineValue
+	rendering
 }
 
 type Origination struct {
@@ -431,6 +537,9 @@ type P struct {
 	Title      []*Title              `xml:"title" json:"title,omitempty"`
 
 	Value string `xml:",innerxml" json:"value,omitempty"`
+
+	inlineValue
+	rendering
 }
 
 type PhysDesc struct {
@@ -442,6 +551,9 @@ type PhysDesc struct {
 	PhysFacet  *PhysFacet          `xml:"physfacet" json:"physfacet,omitempty"`
 
 	Value string `xml:",innerxml" json:"value,omitempty"`
+
+	inlineValue
+	rendering
 }
 
 type PhysFacet struct {
@@ -450,6 +562,9 @@ type PhysFacet struct {
 	Label  FilteredLabelString `xml:"label,attr" json:"label,omitempty"`
 
 	Value string `xml:",innerxml" json:"value,omitempty"`
+
+	inlineValue
+	rendering
 }
 
 type PhysLoc struct {
@@ -457,6 +572,9 @@ type PhysLoc struct {
 	ExtRef []*ExtRef      `xml:"extref" json:"extref,omitempty"`
 
 	Value string `xml:",innerxml" json:"value,omitempty"`
+
+	inlineValue
+	rendering
 }
 
 type ProfileDesc struct {
@@ -475,18 +593,67 @@ type Repository struct {
 	CorpName []*AccessTermWithRole `xml:"corpname" json:"corpname,omitempty"`
 
 	Value string `xml:",innerxml" json:"value,omitempty"`
+
+	inlineValue
+	rendering
 }
 
 type RevisionDesc struct {
 	Change []*Change `xml:"change" json:"change,omitempty"`
 }
 
+type Row struct {
+	RowSep FilteredString `xml:"rowsep,attr" json:"rowsep,omitempty"`
+	VAlign FilteredString `xml:"valign,attr" json:"valign,omitempty"`
+
+	Entry []*Entry `xml:"entry" json:"entry,omitempty"`
+}
+
+type Table struct {
+	ColSep FilteredString `xml:"colsep,attr" json:"colsep,omitempty"`
+	Frame  FilteredString `xml:"frame,attr" json:"frame,omitempty"`
+	ID     FilteredString `xml:"id,attr" json:"id,omitempty"`
+	PgWide FilteredString `xml:"pgwide,attr" json:"pgwide,omitempty"`
+	RowSep FilteredString `xml:"rowsep,attr" json:"rowsep,omitempty"`
+
+	Head   *Head     `xml:"head" json:"head,omitempty"`
+	TGroup []*TGroup `xml:"tgroup" json:"tgroup,omitempty"`
+
+	rendering
+}
+
+type TBody struct {
+	VAlign FilteredString `xml:"valign,attr" json:"valign,omitempty"`
+
+	Row []*Row `xml:"row" json:"row,omitempty"`
+}
+
+type TGroup struct {
+	Align  FilteredString `xml:"align,attr" json:"align,omitempty"`
+	Cols   FilteredString `xml:"cols,attr" json:"cols,omitempty"`
+	ColSep FilteredString `xml:"colsep,attr" json:"colsep,omitempty"`
+	RowSep FilteredString `xml:"rowsep,attr" json:"rowsep,omitempty"`
+
+	ColSpec []*ColSpec `xml:"colspec" json:"colspec,omitempty"`
+	THead   *THead     `xml:"thead" json:"thead,omitempty"`
+	TBody   *TBody     `xml:"tbody" json:"tbody,omitempty"`
+}
+
+type THead struct {
+	VAlign FilteredString `xml:"valign,attr" json:"valign,omitempty"`
+
+	Row []*Row `xml:"row" json:"row,omitempty"`
+}
+
 type Title struct {
 	Render FilteredString `xml:"render,attr" json:"render,omitempty"`
 	Source FilteredString `xml:"source,attr" json:"source,omitempty"`
 	Type   FilteredString `xml:"type,attr" json:"type,omitempty"`
 
 	Value string `xml:",innerxml" json:"value,omitempty"`
+
+	inlineValue
+	rendering
 }
 
 type TitleProper struct {
@@ -495,6 +662,8 @@ type TitleProper struct {
 	Num []*Num `xml:"num" json:"num,omitempty"`
 
 	Value string `xml:",innerxml" json:"value,omitempty"`
+
+	rendering
 }
 
 type TitleStmt struct {
@@ -513,6 +682,8 @@ type UnitDate struct {
 	Normal   FilteredString `xml:"normal,attr" json:"normal,omitempty"`
 
 	Value string `xml:",innerxml" json:"value,omitempty"`
+
+	rendering
 }
 
 // UnitID does not have any JSON tags because UnitID only appears as part of a DID
@@ -529,4 +700,7 @@ type UnitTitle struct {
 	Title    []*Title              `xml:"title" json:"title,omitempty"`
 
 	Value string `xml:",innerxml" json:"value,omitempty"`
+
+	inlineValue
+	rendering
 }
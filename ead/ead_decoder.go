@@ -1,10 +1,66 @@
 package ead
 
 import (
+	"bytes"
 	"encoding/xml"
 	"fmt"
+	"io"
+	"reflect"
+	"regexp"
 )
 
+// DecodeMode determines how Decode handles elements and attributes that are
+// not part of the data model
+type DecodeMode int
+
+const (
+	// Strict decoding fails on elements that are not supported by EADChild,
+	// like xml.Unmarshal
+	Strict DecodeMode = iota
+	// Lenient decoding preserves elements that are not supported by EADChild
+	// as RawElement children, and records a Warning for each element and
+	// attribute that is not part of the data model
+	Lenient
+)
+
+// lenientEAD decodes an EAD without the check for RawElement children of
+// EAD.UnmarshalXML
+type lenientEAD EAD
+
+// Decode decodes an EAD from r.  Decode(r, Strict) is equivalent to
+// xml.Unmarshal.  Decode(r, Lenient) only fails if the XML is not
+// well-formed, and records the elements and attributes that did not make
+// it into the data model in the EAD Warnings.
+//
+// Decode(r, Strict) decodes directly from r.  Decode(r, Lenient) reads all
+// of r into memory, because the XML is read a second time for the Warnings.
+func Decode(r io.Reader, mode DecodeMode) (*EAD, error) {
+	if mode != Lenient {
+		var e EAD
+		if err := xml.NewDecoder(r).Decode(&e); err != nil {
+			return nil, err
+		}
+		return &e, nil
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var e EAD
+	if err := xml.NewDecoder(bytes.NewReader(data)).Decode((*lenientEAD)(&e)); err != nil {
+		return nil, err
+	}
+
+	e.Warnings, err = getWarnings(data, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &e, nil
+}
+
 // The following code was developed by Don Mennerich
 // some references:
 // 	https://stackoverflow.com/a/38850984
@@ -15,49 +71,138 @@ type EADChild struct {
 	Value interface{} `json:"value,omitempty"`
 }
 
+// RawElement is an element that is not supported by EADChild.
+// Lenient decoding preserves such elements as RawElements instead of failing.
+// xml.Unmarshal of a type other than EAD also preserves them.
+type RawElement struct {
+	Attr  []xml.Attr `xml:",any,attr" json:"-"`
+	Value string     `xml:",innerxml" json:"-"`
+
+	rendering
+}
+
+// UnmarshalXML decodes the element into the type returned by
+// newEADChildValue(), or into a RawElement if the element is not supported.
+// Decoding an EAD fails on RawElement children afterwards, unless it is
+// decoded with Decode(r, Lenient), see checkSupportedElements().
 func (eadChild *EADChild) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
-	name := start.Name.Local
+	e := newEADChildValue(start.Name.Local)
+	if e == nil {
+		e = &RawElement{}
+	}
+	return decodeElement(eadChild, e, d, start)
+}
+
+// UnmarshalXML decodes the EAD.  Elements that are not supported by EADChild
+// are an error, see Decode() for lenient decoding.
+func (e *EAD) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	if err := d.DecodeElement((*lenientEAD)(e), &start); err != nil {
+		return err
+	}
+	return checkSupportedElements(e)
+}
+
+// checkSupportedElements returns an error for the first RawElement child
+// decoded into v.  The check runs on the decoded values rather than in
+// EADChild.UnmarshalXML, so it does not depend on the decoder that decoded
+// the children.
+func checkSupportedElements(v any) error {
+	var name string
+	walkDecodedValues(reflect.ValueOf(v), func(v reflect.Value) bool {
+		if eadChild, ok := v.Addr().Interface().(*EADChild); ok {
+			if _, ok := eadChild.Value.(*RawElement); ok {
+				name = eadChild.Name
+				return false
+			}
+		}
+		return true
+	})
+
+	if name != "" {
+		return fmt.Errorf("unsupported element error: %s", name)
+	}
+	return nil
+}
+
+// walkDecodedValues calls fn for each addressable struct value in v, until
+// fn returns false.  Fields that are not decoded, e.g., the C Parent, are
+// skipped.  walkDecodedValues returns false if the walk was stopped.
+func walkDecodedValues(v reflect.Value, fn func(v reflect.Value) bool) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return true
+		}
+		return walkDecodedValues(v.Elem(), fn)
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if !walkDecodedValues(v.Index(i), fn) {
+				return false
+			}
+		}
+	case reflect.Struct:
+		if v.CanAddr() && !fn(v) {
+			return false
+		}
+		for i := 0; i < v.NumField(); i++ {
+			sf := v.Type().Field(i)
+			if !sf.IsExported() || sf.Tag.Get("xml") == "-" {
+				continue
+			}
+			if !walkDecodedValues(v.Field(i), fn) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// newEADChildValue returns a pointer to a new value of the type of the
+// element, or nil if the element is not supported
+func newEADChildValue(name string) any {
 	switch name {
 	case "accessrestrict", "accruals", "acqinfo", "altformavail", "appraisal", "arrangement", "bioghist",
 		"custodhist", "fileplan", "materialspec", "odd", "originalsloc", "otherfindaid", "phystech", "prefercite",
 		"processinfo", "relatedmaterial", "scopecontent", "separatedmaterial", "userestrict":
-		e := FormattedNoteWithHead{}
-		return decodeElement(eadChild, &e, d, start)
+		return &FormattedNoteWithHead{}
+	case "address":
+		return &Address{}
 	case "bibliography":
-		e := Bibliography{}
-		return decodeElement(eadChild, &e, d, start)
+		return &Bibliography{}
 	case "bibref":
-		e := BibRef{}
-		return decodeElement(eadChild, &e, d, start)
+		return &BibRef{}
+	case "blockquote":
+		return &BlockQuote{}
 	case "controlaccess":
-		e := ControlAccess{}
-		return decodeElement(eadChild, &e, d, start)
+		return &ControlAccess{}
 	case "chronlist":
-		e := ChronList{}
-		return decodeElement(eadChild, &e, d, start)
+		return &ChronList{}
+	case "dao":
+		return &DAO{}
+	case "daogrp":
+		return &DAOGrp{}
 	case "defitem":
-		e := DefItem{}
-		return decodeElement(eadChild, &e, d, start)
+		return &DefItem{}
 	case "did":
-		e := DID{}
-		return decodeElement(eadChild, &e, d, start)
+		return &DID{}
 	case "dsc":
-		e := DSC{}
-		return decodeElement(eadChild, &e, d, start)
+		return &DSC{}
 	case "extref":
-		e := ExtRef{}
-		return decodeElement(eadChild, &e, d, start)
+		return &ExtRef{}
+	case "index":
+		return &Index{}
 	case "legalstatus":
-		e := LegalStatus{}
-		return decodeElement(eadChild, &e, d, start)
+		return &LegalStatus{}
 	case "list":
-		e := List{}
-		return decodeElement(eadChild, &e, d, start)
+		return &List{}
+	case "note":
+		return &Note{}
 	case "p":
-		e := P{}
-		return decodeElement(eadChild, &e, d, start)
+		return &P{}
+	case "table":
+		return &Table{}
 	default:
-		return fmt.Errorf("unsupported element error: %s", name)
+		return nil
 	}
 }
 
@@ -69,3 +214,81 @@ func decodeElement(eadChild *EADChild, strct any, d *xml.Decoder, start xml.Star
 	eadChild.Value = strct
 	return nil
 }
+
+// UnmarshalXML sets the Barcode from the @label after decoding the <container>,
+// and parses the Value like the generated UnmarshalXML methods
+func (container *Container) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type containerAlias Container
+	if err := d.DecodeElement((*containerAlias)(container), &start); err != nil {
+		return err
+	}
+	container.Barcode = containerLabelBarcode(string(container.Label))
+	container.inlineValue.parse(container.Value)
+	return nil
+}
+
+// numberedComponents holds the numbered components, <c01> to <c12>, of a
+// <dsc> or component, which are decoded into the same C tree as <c>
+type numberedComponents struct {
+	C01 []*C `xml:"c01"`
+	C02 []*C `xml:"c02"`
+	C03 []*C `xml:"c03"`
+	C04 []*C `xml:"c04"`
+	C05 []*C `xml:"c05"`
+	C06 []*C `xml:"c06"`
+	C07 []*C `xml:"c07"`
+	C08 []*C `xml:"c08"`
+	C09 []*C `xml:"c09"`
+	C10 []*C `xml:"c10"`
+	C11 []*C `xml:"c11"`
+	C12 []*C `xml:"c12"`
+}
+
+// components returns the numbered components in document order.  EAD 2002
+// only allows the numbered components of a single level as the children of
+// an element, so the components are returned level by level.
+func (nc *numberedComponents) components() []*C {
+	var cs []*C
+	for _, level := range [][]*C{nc.C01, nc.C02, nc.C03, nc.C04, nc.C05, nc.C06, nc.C07, nc.C08, nc.C09, nc.C10, nc.C11, nc.C12} {
+		cs = append(cs, level...)
+	}
+	return cs
+}
+
+var componentNameRegexp = regexp.MustCompile(`^c(0[1-9]|1[0-2])?$`)
+
+// IsComponentName returns true for the component element names: "c" and
+// the numbered components "c01" to "c12"
+func IsComponentName(name string) bool {
+	return componentNameRegexp.MatchString(name)
+}
+
+// UnmarshalXML decodes the <dsc>, with the numbered components, e.g., <c01>,
+// decoded as <c>
+func (dsc *DSC) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type dscAlias DSC
+	aux := struct {
+		*dscAlias
+		numberedComponents
+	}{dscAlias: (*dscAlias)(dsc)}
+	if err := d.DecodeElement(&aux, &start); err != nil {
+		return err
+	}
+	dsc.C = append(dsc.C, aux.components()...)
+	return nil
+}
+
+// UnmarshalXML decodes the component, with the numbered child components,
+// e.g., <c02>, decoded as <c>
+func (c *C) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type cAlias C
+	aux := struct {
+		*cAlias
+		numberedComponents
+	}{cAlias: (*cAlias)(c)}
+	if err := d.DecodeElement(&aux, &start); err != nil {
+		return err
+	}
+	c.C = append(c.C, aux.components()...)
+	return nil
+}
@@ -4,10 +4,15 @@ import (
 	"bytes"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
+	"testing/iotest"
 )
 
 var testFixtureDataPath = filepath.Join("testdata", "xmlorder")
@@ -66,3 +71,245 @@ func TestStreamParsingEmbeddedPresentationElements(t *testing.T) {
 
 	runiJSONComparisonTest(t, &params)
 }
+
+var blockElementsTestFixturePath = filepath.Join(testFixturePath, "block-elements")
+
+func TestJSONMarshalingWithBlockElements(t *testing.T) {
+	var params iJSONTestParams
+
+	params.TestName = "JSON Marshaling with <table>, <blockquote>, <note>, <index>, <address>, <daogrp>, and <dao>"
+	params.EADFilePath = filepath.Join(blockElementsTestFixturePath, "block-elements.xml")
+	params.JSONReferenceFilePath = filepath.Join(blockElementsTestFixturePath, "block-elements.json")
+	params.JSONErrorFilePath = "./testdata/tmp/failing-block-elements.json"
+
+	runiJSONComparisonTest(t, &params)
+}
+
+func TestBlockElementsXMLRoundTrip(t *testing.T) {
+	t.Run("Block Elements XML Round Trip", func(t *testing.T) {
+		sut := getTestEAD(t, filepath.Join(blockElementsTestFixturePath, "block-elements.xml"))
+		xmlData, roundTripped := marshalAndUnmarshalEAD(t, sut)
+
+		assertContains(t, xmlData, `<entry namest="c2" nameend="c3">Extent</entry>`, "<entry> spans")
+		assertContains(t, xmlData, `<daoloc xlink:href="https://hdl.handle.net/2333.1/example"`, "<daogrp>")
+
+		want, err := json.Marshal(sut)
+		failOnError(t, err, "Unexpected error marshaling JSON")
+		got, err := json.Marshal(roundTripped)
+		failOnError(t, err, "Unexpected error marshaling JSON")
+		assertEqual(t, string(want), string(got), "Round-tripped JSON")
+	})
+}
+
+func getBlockElementsXMLWithUnsupportedElement(t *testing.T) []byte {
+	EADXML, err := os.ReadFile(filepath.Join(blockElementsTestFixturePath, "block-elements.xml"))
+	failOnError(t, err, "Unexpected error reading EAD")
+
+	EADXML = bytes.Replace(EADXML, []byte("<note>"),
+		[]byte(`<head01 render="bold" xlink:href="https://example.org">Tom &amp; <emph>Jerry</emph></head01><note>`), 1)
+	EADXML = bytes.Replace(EADXML, []byte(`<archdesc level="collection">`),
+		[]byte(`<archdesc level="collection"><runner>Draft <emph>only</emph></runner>`), 1)
+	return bytes.Replace(EADXML, []byte("<unitid>"), []byte(`<unitid repositorycode="NNU">`), 1)
+}
+
+func TestDecode(t *testing.T) {
+	t.Run("Decode Strict", func(t *testing.T) {
+		EADXML, err := os.ReadFile(filepath.Join(blockElementsTestFixturePath, "block-elements.xml"))
+		failOnError(t, err, "Unexpected error reading EAD")
+
+		sut, err := Decode(bytes.NewReader(EADXML), Strict)
+		failOnError(t, err, "Unexpected error decoding EAD")
+
+		var want EAD
+		err = xml.Unmarshal(EADXML, &want)
+		failOnError(t, err, "Unexpected error unmarshaling EAD")
+
+		wantJSON, err := json.Marshal(&want)
+		failOnError(t, err, "Unexpected error marshaling JSON")
+		gotJSON, err := json.Marshal(sut)
+		failOnError(t, err, "Unexpected error marshaling JSON")
+		assertEqual(t, string(wantJSON), string(gotJSON), "Decode(r, Strict) JSON")
+
+		_, err = Decode(bytes.NewReader(getBlockElementsXMLWithUnsupportedElement(t)), Strict)
+		if err == nil || err.Error() != "unsupported element error: head01" {
+			t.Errorf("Expected an unsupported element error, got %v", err)
+		}
+
+		err = xml.Unmarshal(getBlockElementsXMLWithUnsupportedElement(t), &want)
+		if err == nil || err.Error() != "unsupported element error: head01" {
+			t.Errorf("Expected an unsupported element error from xml.Unmarshal, got %v", err)
+		}
+
+		_, err = StreamEAD(bytes.NewReader(getBlockElementsXMLWithUnsupportedElement(t)), StreamHandler{})
+		if err == nil || err.Error() != "unsupported element error: head01" {
+			t.Errorf("Expected an unsupported element error from StreamEAD, got %v", err)
+		}
+	})
+
+	t.Run("Decode Strict Children Decoded With Another Decoder", func(t *testing.T) {
+		// the mode does not depend on the decoder of the children
+		var note FormattedNoteWithHead
+		err := xml.Unmarshal([]byte(`<scopecontent><head01>Scope</head01><p>Letters</p></scopecontent>`), &note)
+		failOnError(t, err, "Unexpected error unmarshaling <scopecontent>")
+
+		err = checkSupportedElements(&EAD{ArchDesc: &ArchDesc{ScopeContent: []*FormattedNoteWithHead{&note}}})
+		if err == nil || err.Error() != "unsupported element error: head01" {
+			t.Errorf("Expected an unsupported element error, got %v", err)
+		}
+	})
+
+	t.Run("Decode Strict Without Buffering", func(t *testing.T) {
+		EADXML, err := os.ReadFile(filepath.Join(blockElementsTestFixturePath, "block-elements.xml"))
+		failOnError(t, err, "Unexpected error reading EAD")
+
+		// a strict decode stops at the end of the <ead>, so the read error
+		// after it is not reached
+		readErr := errors.New("read past the end of the EAD")
+		r := io.MultiReader(bytes.NewReader(EADXML), iotest.ErrReader(readErr))
+		_, err = Decode(r, Strict)
+		failOnError(t, err, "Unexpected error decoding EAD")
+
+		r = io.MultiReader(bytes.NewReader(EADXML), iotest.ErrReader(readErr))
+		_, err = Decode(r, Lenient)
+		if !errors.Is(err, readErr) {
+			t.Errorf("Expected the read error for a lenient decode, got %v", err)
+		}
+	})
+
+	t.Run("Decode Lenient", func(t *testing.T) {
+		sut, err := Decode(bytes.NewReader(getBlockElementsXMLWithUnsupportedElement(t)), Lenient)
+		failOnError(t, err, "Unexpected error decoding EAD")
+
+		children := sut.ArchDesc.ScopeContent[0].Children
+		var raw *RawElement
+		for _, child := range children {
+			if child.Name == "head01" {
+				raw = child.Value.(*RawElement)
+			}
+		}
+		if raw == nil {
+			t.Fatalf("Expected a <head01> RawElement child")
+		}
+		assertEqual(t, "Tom &amp; <emph>Jerry</emph>", raw.Value, "RawElement Value")
+
+		jsonData, err := json.Marshal(raw)
+		failOnError(t, err, "Unexpected error marshaling JSON")
+		assertEqual(t, `{"attributes":{"href":"https://example.org","render":"bold"},"value":"Tom \u0026amp; \u003cspan class=\"ead-emph\"\u003eJerry\u003c/span\u003e"}`,
+			string(jsonData), "RawElement JSON")
+
+		xmlData, err := xml.Marshal(sut)
+		failOnError(t, err, "Unexpected error marshaling XML")
+		assertContains(t, string(xmlData), `<head01 render="bold" xlink:href="https://example.org">Tom &amp; <emph>Jerry</emph></head01>`, "RawElement XML")
+
+		_, err = Decode(bytes.NewReader(xmlData), Lenient)
+		failOnError(t, err, "Unexpected error decoding marshaled XML")
+
+		_, err = json.Marshal(sut)
+		failOnError(t, err, "Unexpected error marshaling JSON")
+	})
+
+	t.Run("Decode Lenient Warnings", func(t *testing.T) {
+		sut, err := Decode(bytes.NewReader(getBlockElementsXMLWithUnsupportedElement(t)), Lenient)
+		failOnError(t, err, "Unexpected error decoding EAD")
+
+		var got []string
+		for _, warning := range sut.Warnings {
+			got = append(got, warning.String())
+		}
+		want := []string{
+			"skipped attribute: /ead[1]/eadheader[1]/@countryencoding",
+			"skipped attribute: /ead[1]/eadheader[1]/@dateencoding",
+			"skipped attribute: /ead[1]/eadheader[1]/@findaidstatus",
+			"skipped attribute: /ead[1]/eadheader[1]/@langencoding",
+			"skipped attribute: /ead[1]/eadheader[1]/@repositoryencoding",
+			"skipped element: /ead[1]/archdesc[1]/runner[1]",
+			"skipped attribute: /ead[1]/archdesc[1]/did[1]/unitid[1]/@repositorycode",
+			"unsupported element preserved as raw element: /ead[1]/archdesc[1]/scopecontent[1]/head01[1]",
+			"skipped element: /ead[1]/archdesc[1]/odd[1]/index[1]/indexentry[1]/persname[1]",
+			"skipped attribute: /ead[1]/archdesc[1]/odd[1]/index[1]/indexentry[1]/ref[1]/@target",
+		}
+		assertEqual(t, strings.Join(want, "\n"), strings.Join(got, "\n"), "Warnings")
+
+		EADXML, err := os.ReadFile(filepath.Join(blockElementsTestFixturePath, "block-elements.xml"))
+		failOnError(t, err, "Unexpected error reading EAD")
+		sut, err = Decode(bytes.NewReader(EADXML), Strict)
+		failOnError(t, err, "Unexpected error decoding EAD")
+		if len(sut.Warnings) != 0 {
+			t.Errorf("Expected no warnings from strict decoding, got %v", sut.Warnings)
+		}
+	})
+
+	t.Run("Decode Lenient Paths", func(t *testing.T) {
+		sut, err := Decode(strings.NewReader(`<ead><archdesc><dsc><c><did/></c><c><did><unitid/><unitid audience="internal"/></did></c></dsc></archdesc></ead>`), Lenient)
+		failOnError(t, err, "Unexpected error decoding EAD")
+		if len(sut.Warnings) != 1 {
+			t.Fatalf("Expected 1 warning, got %v", sut.Warnings)
+		}
+		assertEqual(t, "/ead[1]/archdesc[1]/dsc[1]/c[2]/did[1]/unitid[2]/@audience", sut.Warnings[0].Path, "Warning Path")
+
+		_, err = Decode(strings.NewReader(`<ead><archdesc>`), Lenient)
+		if err == nil {
+			t.Errorf("Expected an error decoding XML that is not well-formed")
+		}
+	})
+}
+
+// Omega-EAD-numbered.xml is Omega-EAD.xml with numbered components, converted
+// by modify.NumberComponents()
+var numberedComponentsTestFixturePath = filepath.Join(testFixturePath, "numbered-components")
+
+func TestNumberedComponents(t *testing.T) {
+	numberedEADXML, err := os.ReadFile(filepath.Join(numberedComponentsTestFixturePath, "Omega-EAD-numbered.xml"))
+	failOnError(t, err, "Unexpected error reading EAD")
+
+	t.Run("Unmarshal Numbered Components", func(t *testing.T) {
+		omega := getOmegaEAD(t)
+		want, err := json.Marshal(omega)
+		failOnError(t, err, "Unexpected error marshaling JSON")
+
+		var sut EAD
+		err = xml.Unmarshal(numberedEADXML, &sut)
+		failOnError(t, err, "Unexpected error unmarshaling EAD")
+		got, err := json.Marshal(sut)
+		failOnError(t, err, "Unexpected error marshaling JSON")
+
+		assertEqual(t, string(want), string(got), "Numbered Components iJSON")
+	})
+
+	t.Run("Stream Numbered Components", func(t *testing.T) {
+		var topLevelCount, count int
+		_, err := StreamEAD(bytes.NewReader(numberedEADXML), StreamHandler{
+			C: func(e *EAD, c *C, index int) error {
+				topLevelCount++
+				return WalkCs([]*C{c}, func(c *C, info *WalkInfo) error {
+					count++
+					return nil
+				})
+			},
+		})
+		failOnError(t, err, "Unexpected error streaming EAD")
+
+		assertEqual(t, "2", fmt.Sprint(topLevelCount), "Top-Level Component Count")
+		assertEqual(t, "17", fmt.Sprint(count), "Component Count")
+	})
+
+	t.Run("Decode Lenient Numbered Components", func(t *testing.T) {
+		omegaXML, err := os.ReadFile(filepath.Join(omegaTestFixturePath, "Omega-EAD.xml"))
+		failOnError(t, err, "Unexpected error reading EAD")
+		want, err := Decode(bytes.NewReader(omegaXML), Lenient)
+		failOnError(t, err, "Unexpected error decoding EAD")
+
+		sut, err := Decode(bytes.NewReader(numberedEADXML), Lenient)
+		failOnError(t, err, "Unexpected error decoding EAD")
+
+		unnumbered := regexp.MustCompile(`/c\d\d`)
+		for i, warning := range sut.Warnings {
+			if i >= len(want.Warnings) {
+				t.Errorf("Unexpected warning: %s", warning)
+				continue
+			}
+			assertEqual(t, want.Warnings[i].String(), unnumbered.ReplaceAllString(warning.String(), "/c"), "Warning")
+		}
+		assertEqual(t, fmt.Sprint(len(want.Warnings)), fmt.Sprint(len(sut.Warnings)), "Warning Count")
+	})
+}
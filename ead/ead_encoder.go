@@ -0,0 +1,302 @@
+package ead
+
+import (
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	EADNamespace   = "urn:isbn:1-931666-22-9"
+	XLinkNamespace = "http://www.w3.org/1999/xlink"
+)
+
+// MarshalXML writes the EAD as EAD 2002 XML in the urn:isbn:1-931666-22-9 namespace.
+//
+// The struct tags used for unmarshaling are reused, with the following rules:
+//   - elements with mixed content are unmarshaled into both a Value field
+//     (",innerxml") and child element fields.  Value is the source of truth:
+//     if it is not empty it is written as-is, and the child element fields
+//     are ignored.  Edit Value to change mixed content.
+//   - fields without an xml tag, e.g., DAO.DOType, are not written
+//   - empty attributes are not written.  Empty elements are only written if
+//     they are referenced by a non-nil pointer, e.g., an empty <dsc/>
+//   - child elements are written in the order required by the EAD schema,
+//     so the order of sibling elements in the source EAD may not be preserved
+//
+// Elements and attributes that are not part of the data model are not
+// preserved when an EAD is unmarshaled, so they are not written either.
+func (e EAD) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	start.Name = xml.Name{Local: "ead"}
+	start.Attr = append(start.Attr,
+		xml.Attr{Name: xml.Name{Local: "xmlns"}, Value: EADNamespace},
+		xml.Attr{Name: xml.Name{Local: "xmlns:xlink"}, Value: XLinkNamespace},
+	)
+
+	return marshalXMLStruct(enc, start, reflect.ValueOf(e))
+}
+
+// MarshalXML writes the child element using its element name
+func (eadChild *EADChild) MarshalXML(enc *xml.Encoder, start xml.StartElement) error {
+	if eadChild.Value == nil {
+		return nil
+	}
+	if raw, ok := eadChild.Value.(*RawElement); ok {
+		return raw.marshalXML(enc, eadChild.Name)
+	}
+	return marshalXMLElement(enc, eadChild.Name, reflect.ValueOf(eadChild.Value))
+}
+
+// marshalXML writes the RawElement as it was decoded
+func (raw *RawElement) marshalXML(enc *xml.Encoder, name string) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	for _, attr := range raw.Attr {
+		// namespace declarations are written by the <ead> element
+		if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+			continue
+		}
+		attrName := attr.Name.Local
+		if attr.Name.Space == XLinkNamespace {
+			attrName = "xlink:" + attrName
+		}
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: attrName}, Value: attr.Value})
+	}
+	return enc.EncodeElement(rawXMLElement{InnerXML: raw.Value}, start)
+}
+
+// the EAD schema requires some child elements to appear in a specific order,
+// which does not match the order of the struct fields
+type xmlFieldOrder struct {
+	first []string
+	last  []string
+}
+
+var xmlFieldOrders = map[reflect.Type]xmlFieldOrder{
+	reflect.TypeOf(EAD{}):         {first: []string{"EADHeader"}},
+	reflect.TypeOf(ArchDesc{}):    {first: []string{"DID"}, last: []string{"DSC"}},
+	reflect.TypeOf(C{}):           {first: []string{"DID"}, last: []string{"C"}},
+	reflect.TypeOf(DefItem{}):     {first: []string{"Label"}},
+	reflect.TypeOf(DSC{}):         {first: []string{"P"}},
+	reflect.TypeOf(FileDesc{}):    {first: []string{"TitleStmt", "EditionStmt", "PublicationStmt"}},
+	reflect.TypeOf(Index{}):       {first: []string{"Head", "P"}},
+	reflect.TypeOf(IndexEntry{}):  {last: []string{"Ref"}},
+	reflect.TypeOf(ProfileDesc{}): {first: []string{"Creation", "LangUsage"}},
+	reflect.TypeOf(TitleStmt{}):   {first: []string{"TitleProper", "SubTitle"}},
+}
+
+// elements whose link attributes are in the xlink namespace
+var xlinkAttributeTypes = map[reflect.Type]bool{
+	reflect.TypeOf(DAO{}):    true,
+	reflect.TypeOf(DAOGrp{}): true,
+	reflect.TypeOf(DAOLoc{}): true,
+	reflect.TypeOf(ExtPtr{}): true,
+	reflect.TypeOf(ExtRef{}): true,
+}
+
+var xlinkAttributes = map[string]bool{
+	"actuate": true,
+	"arcrole": true,
+	"href":    true,
+	"role":    true,
+	"show":    true,
+	"title":   true,
+	"type":    true,
+}
+
+type xmlField struct {
+	index    int
+	goName   string
+	name     string
+	attr     bool
+	innerXML bool
+	any      bool
+}
+
+// rawXMLElement is used to write innerxml values verbatim:
+// the xml.Encoder does not provide a way to write raw XML directly
+type rawXMLElement struct {
+	InnerXML string `xml:",innerxml"`
+}
+
+func marshalXMLElement(enc *xml.Encoder, name string, v reflect.Value) error {
+	// an empty element is only written if it was present in the source EAD,
+	// i.e., if it was unmarshaled into a pointer
+	omitEmpty := v.Kind() != reflect.Pointer
+
+	v = indirect(v)
+	if !v.IsValid() {
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if omitEmpty && isEmptyXMLStruct(v) {
+			return nil
+		}
+		return marshalXMLStruct(enc, xml.StartElement{Name: xml.Name{Local: name}}, v)
+	case reflect.String:
+		if v.String() == "" {
+			return nil
+		}
+		return enc.EncodeElement(v.String(), xml.StartElement{Name: xml.Name{Local: name}})
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if err := marshalXMLElement(enc, name, v.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unable to marshal <%s>: unsupported type %s", name, v.Type())
+	}
+}
+
+func marshalXMLStruct(enc *xml.Encoder, start xml.StartElement, v reflect.Value) error {
+	fields := getXMLFields(v.Type())
+	for _, f := range fields {
+		if !f.attr {
+			continue
+		}
+		value := indirect(v.Field(f.index))
+		if !value.IsValid() || value.Kind() != reflect.String || value.String() == "" {
+			continue
+		}
+		name := f.name
+		if xlinkAttributeTypes[v.Type()] && xlinkAttributes[name] {
+			name = "xlink:" + name
+		}
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: name}, Value: value.String()})
+	}
+
+	for _, f := range fields {
+		if f.innerXML && v.Field(f.index).String() != "" {
+			return enc.EncodeElement(rawXMLElement{InnerXML: v.Field(f.index).String()}, start)
+		}
+	}
+
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+
+	for _, f := range orderXMLFields(v.Type(), fields) {
+		if f.attr || f.innerXML {
+			continue
+		}
+
+		var err error
+		if f.any {
+			err = enc.Encode(v.Field(f.index).Interface())
+		} else {
+			err = marshalXMLElement(enc, f.name, v.Field(f.index))
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return enc.EncodeToken(start.End())
+}
+
+func getXMLFields(t reflect.Type) []xmlField {
+	var fields []xmlField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, ok := sf.Tag.Lookup("xml")
+		if !ok || tag == "-" || !sf.IsExported() {
+			continue
+		}
+
+		name, flags, _ := strings.Cut(tag, ",")
+		f := xmlField{index: i, goName: sf.Name, name: name}
+		for _, flag := range strings.Split(flags, ",") {
+			switch flag {
+			case "attr":
+				f.attr = true
+			case "innerxml":
+				f.innerXML = true
+			case "any":
+				f.any = true
+			}
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+func orderXMLFields(t reflect.Type, fields []xmlField) []xmlField {
+	order, ok := xmlFieldOrders[t]
+	if !ok {
+		return fields
+	}
+
+	rank := func(f xmlField) int {
+		for i, name := range order.first {
+			if f.goName == name {
+				return i - len(order.first)
+			}
+		}
+		for i, name := range order.last {
+			if f.goName == name {
+				return i + 1
+			}
+		}
+		return 0
+	}
+
+	ordered := append([]xmlField{}, fields...)
+	// stable insertion sort: the unranked fields keep the struct field order
+	for i := 1; i < len(ordered); i++ {
+		for j := i; j > 0 && rank(ordered[j]) < rank(ordered[j-1]); j-- {
+			ordered[j], ordered[j-1] = ordered[j-1], ordered[j]
+		}
+	}
+	return ordered
+}
+
+// isEmptyXMLStruct returns true if the struct would be written as an empty element
+func isEmptyXMLStruct(v reflect.Value) bool {
+	for _, f := range getXMLFields(v.Type()) {
+		if !isEmptyXMLValue(v.Field(f.index)) {
+			return false
+		}
+	}
+	return true
+}
+
+func isEmptyXMLValue(v reflect.Value) bool {
+	v = indirect(v)
+	if !v.IsValid() {
+		return true
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String() == ""
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if !isEmptyXMLValue(v.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		if v.Type() == reflect.TypeOf(EADChild{}) {
+			return v.FieldByName("Value").IsNil()
+		}
+		return isEmptyXMLStruct(v)
+	default:
+		return v.IsZero()
+	}
+}
+
+// indirect dereferences pointers and interfaces
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
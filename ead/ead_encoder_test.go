@@ -0,0 +1,129 @@
+package ead
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func marshalAndUnmarshalEAD(t *testing.T, sut *EAD) (string, *EAD) {
+	xmlData, err := xml.Marshal(sut)
+	failOnError(t, err, "Unexpected error marshaling XML")
+
+	var roundTripped EAD
+	err = xml.Unmarshal(xmlData, &roundTripped)
+	failOnError(t, err, "Unexpected error unmarshaling marshaled XML")
+
+	return string(xmlData), &roundTripped
+}
+
+func assertContains(t *testing.T, s string, substr string, label string) {
+	if !strings.Contains(s, substr) {
+		t.Errorf("%s: expected to find %q", label, substr)
+	}
+}
+
+func TestXMLRoundTrip(t *testing.T) {
+	for _, eadPath := range []string{
+		filepath.Join(omegaTestFixturePath, "Omega-EAD.xml"),
+		filepath.Join(omegaTestFixturePath, "mos_2021-with-presentation-elements-in-titlestmt-children.xml"),
+		filepath.Join(presentationComponentPath, "pc-no-components.xml"),
+		filepath.Join(nyuadTestFixturePath, "ad_mc_019-edited.xml"),
+	} {
+		t.Run(filepath.Base(eadPath), func(t *testing.T) {
+			sut := getTestEAD(t, eadPath)
+			_, roundTripped := marshalAndUnmarshalEAD(t, sut)
+
+			want, err := json.MarshalIndent(sut, "", "    ")
+			failOnError(t, err, "Unexpected error marshaling JSON")
+			got, err := json.MarshalIndent(roundTripped, "", "    ")
+			failOnError(t, err, "Unexpected error marshaling JSON")
+
+			if string(want) != string(got) {
+				t.Errorf("Round-tripped EAD iJSON does not match the original EAD iJSON")
+			}
+		})
+	}
+}
+
+func TestXMLMarshalingNamespaces(t *testing.T) {
+	t.Run("XML Marshaling Namespaces", func(t *testing.T) {
+		sut := getOmegaEAD(t)
+		xmlData, _ := marshalAndUnmarshalEAD(t, &sut)
+
+		want := `<ead xmlns="urn:isbn:1-931666-22-9" xmlns:xlink="http://www.w3.org/1999/xlink"><eadheader><eadid`
+		if !strings.HasPrefix(xmlData, want) {
+			t.Errorf("Expected marshaled XML to start with %q, got %q", want, xmlData[:len(want)])
+		}
+
+		assertContains(t, xmlData, `<dao xlink:actuate="onRequest" xlink:href="https://hdl.handle.net/2333.1/7h44j74d"`, "DAO xlink attributes")
+		assertContains(t, xmlData, `<persname role="dnr">`, "Non-xlink role attribute")
+	})
+}
+
+func TestXMLMarshalingElementOrder(t *testing.T) {
+	t.Run("XML Marshaling Element Order", func(t *testing.T) {
+		sut := getOmegaEAD(t)
+		xmlData, _ := marshalAndUnmarshalEAD(t, &sut)
+
+		assertContains(t, xmlData, `<archdesc level="collection"><did>`, "<archdesc> <did> first")
+		assertContains(t, xmlData, `<filedesc><titlestmt>`, "<filedesc> <titlestmt> first")
+		assertContains(t, xmlData, `<c id="aspace_499449c48c751a22b7c222d3ce2c2879" level="series"><did>`, "<c> <did> first")
+
+		// nested components must follow all other component children
+		sut.ArchDesc.DSC.C = []*C{{
+			ID:    "parent",
+			Level: "series",
+			C: []*C{{
+				ID:  "child",
+				DID: DID{UnitTitle: &UnitTitle{Value: "Child"}},
+			}},
+			ScopeContent: []*FormattedNoteWithHead{{Value: "<p>Scope</p>"}},
+			DID:          DID{UnitTitle: &UnitTitle{Value: "Parent"}},
+		}}
+
+		xmlData, _ = marshalAndUnmarshalEAD(t, &sut)
+		assertContains(t, xmlData, `<dsc><c id="parent" level="series"><did><unittitle>Parent</unittitle></did><scopecontent><p>Scope</p></scopecontent><c id="child"><did><unittitle>Child</unittitle></did></c></c></dsc>`, "<c> nested <c> last")
+		if !strings.HasSuffix(xmlData, "</dsc></archdesc></ead>") {
+			t.Errorf("Expected <dsc> to be the last <archdesc> child")
+		}
+	})
+}
+
+func TestXMLMarshalingEmptyElements(t *testing.T) {
+	t.Run("XML Marshaling Empty Elements", func(t *testing.T) {
+		sut := getPresentationComponentEAD(t, "pc-no-components.xml")
+		xmlData, roundTripped := marshalAndUnmarshalEAD(t, &sut)
+
+		assertContains(t, xmlData, "<dsc></dsc>", "Empty <dsc> present in the source EAD")
+		if roundTripped.ArchDesc.DSC == nil {
+			t.Errorf("Expected the round-tripped EAD to have a <dsc>")
+		}
+
+		// values without xml tags are not written
+		sut = getOmegaEAD(t)
+		sut.ArchDesc.DSC.C[0].DID.DAO[0].DOType = "image_set"
+		sut.ArchDesc.DSC.C[0].DID.DAO[0].Count = 32
+		xmlData, _ = marshalAndUnmarshalEAD(t, &sut)
+		for _, s := range []string{"image_set", "DOType", "<Count>", "<runinfo>", "<pubinfo>"} {
+			if strings.Contains(xmlData, s) {
+				t.Errorf("Unexpected %q in marshaled XML", s)
+			}
+		}
+	})
+}
+
+func TestXMLMarshalingEditedMixedContent(t *testing.T) {
+	t.Run("XML Marshaling Edited Mixed Content", func(t *testing.T) {
+		sut := getOmegaEAD(t)
+		sut.ArchDesc.DID.UnitTitle.Value = `New <emph render="italic">title</emph>`
+
+		_, roundTripped := marshalAndUnmarshalEAD(t, &sut)
+		assertEqual(t, sut.ArchDesc.DID.UnitTitle.Value, roundTripped.GuideTitle(), "Edited <unittitle>")
+		if len(roundTripped.ArchDesc.DID.UnitTitle.Title) != 0 {
+			t.Errorf("Expected the edited <unittitle> to have no <title> children")
+		}
+	})
+}
@@ -17,7 +17,7 @@ import (
 const convertTextWithTagsMarshalJSONCodeTemplate = `func ({{.VarName}} *{{.TypeName}}) MarshalJSON() ([]byte, error) {
 	type {{.TypeName}}WithTags {{.TypeName}}
 
-	result, err := {{.ConversionFunction}}({{.VarName}}.Value)
+	content, err := {{.VarName}}.Inline()
 	if err != nil {
 		return nil, err
 	}
@@ -26,7 +26,7 @@ const convertTextWithTagsMarshalJSONCodeTemplate = `func ({{.VarName}} *{{.TypeN
 		Value string ` + "`" + `json:"value,omitempty"` + "`\n" +
 	`		*{{.TypeName}}WithTags
 	}{
-		Value:             string(result),
+		Value:             {{.VarName}}.htmlRenderer().{{.ConversionFunction}}(content),
 		{{.TypeName}}WithTags: (*{{.TypeName}}WithTags)({{.VarName}}),
 	})
 	if err != nil {
@@ -46,12 +46,12 @@ const omitWhitespaceOnlyValueFieldsAndConvertTextWithTagsMarshalJSONCodeTemplate
 
 	var value string
 	if containsNonWhitespace {
-		result, err := {{.ConversionFunction}}({{.VarName}}.Value)
+		content, err := {{.VarName}}.Inline()
 		if err != nil {
 			return nil, err
 		}
 
-		value = string(result)
+		value = {{.VarName}}.htmlRenderer().{{.ConversionFunction}}(content)
 	} else {
 		value = ""
 	}
@@ -70,6 +70,80 @@ const omitWhitespaceOnlyValueFieldsAndConvertTextWithTagsMarshalJSONCodeTemplate
 	return jsonData, nil
 }`
 
+const unmarshalXMLCodeTemplate = `// UnmarshalXML decodes the {{.TypeName}}, and parses its Value for Inline()
+func ({{.VarName}} *{{.TypeName}}) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type {{.TypeName}}Alias {{.TypeName}}
+	if err := d.DecodeElement((*{{.TypeName}}Alias)({{.VarName}}), &start); err != nil {
+		return err
+	}
+	{{.VarName}}.inlineValue.parse({{.VarName}}.Value)
+	return nil
+}`
+
+const inlineCodeTemplate = `// Inline returns the mixed content of the {{.TypeName}} Value.  The Value is
+// parsed once when the {{.TypeName}} is decoded, or on each call if the Value
+// was not decoded or has been changed since.  The InlineContent is shared, so
+// it must not be modified.
+func ({{.VarName}} *{{.TypeName}}) Inline() (InlineContent, error) {
+	return {{.VarName}}.inlineValue.inline({{.VarName}}.Value)
+}`
+
+const plainTextCodeTemplate = `// PlainText renders the {{.TypeName}} Value as plain text, see InlineContent.PlainText().
+// A Value that is not well-formed is rendered with the tags removed.
+func ({{.VarName}} *{{.TypeName}}) PlainText() string {
+	content, err := {{.VarName}}.Inline()
+	if err != nil {
+		return getPlainText({{.VarName}}.Value)
+	}
+	return content.PlainText()
+}`
+
+// Container has its own UnmarshalXML method, which also parses the Value
+var customUnmarshalXMLTypes = map[string]bool{
+	"Container": true,
+}
+
+var convertTextWithTagsConversionFunctionsForTypes = map[string]string{
+	"Abstract": "HTML",
+	// Do not add AccessTermWithRole because it has a unique MarshalJSON method
+	// already defined which does relator code translation.
+	"AddressLine": "HTML",
+	"ArchRef":     "HTML",
+	"BibRef":      "HTML",
+	"ChronItem":   "HTML",
+	"Container":   "HTML",
+	"Creation":    "HTML",
+	// Do not add DAO because it requires custom marshaling.
+	"Date": "HTML",
+	// Do not add DID because it requires custom marshaling.
+	"Dimensions": "HTML",
+	"Entry":      "HTML",
+	"Event":      "HTML",
+	// Extent has custom marshaling requirements and is therefore not generated.
+	"Head": "HTML",
+	// Do not add IndexEntry because it requires custom marshaling.
+	"Item":         "HTML",
+	"LangMaterial": "HTML",
+	"LangUsage":    "HTML",
+	"LegalStatus":  "HTML",
+	"Num":          "HTML",
+	"P":            "HTML",
+	"PhysFacet":    "HTML",
+	// Do not add PhysDesc, whose MarshalJSON is created by generator
+	// writeOmitWhitespaceOnlyValueFieldsAndConvertTextWithTagsCodeToBuffer.
+	"PhysLoc":    "HTML",
+	"Repository": "HTML",
+	"Title":      "HTMLNoLBConversion",
+	// Do not add TitleProper because it requires custom marshaling.
+	// Do not add TitleStmt   because it requires custom marshaling.
+	// Do not add UnitDate because it requires custom marshaling.
+	"UnitTitle": "HTML",
+}
+
+var omitWhitespaceOnlyValueFieldsAndConvertTextWithTagsConversionFunctionsForTypes = map[string]string{
+	"PhysDesc": "HTML",
+}
+
 func main() {
 	w := new(bytes.Buffer)
 
@@ -79,11 +153,15 @@ package ead
 
 import (
 	"encoding/json"
+	"encoding/xml"
 	"regexp"
 )`)
 
 	writeConvertTextWithTagsCodeToBuffer(w)
 	writeOmitWhitespaceOnlyValueFieldsAndConvertTextWithTagsCodeToBuffer(w)
+	writeValueMethodCodeToBuffer(w, unmarshalXMLCodeTemplate, customUnmarshalXMLTypes)
+	writeValueMethodCodeToBuffer(w, inlineCodeTemplate, nil)
+	writeValueMethodCodeToBuffer(w, plainTextCodeTemplate, nil)
 
 	// Format with gofmt
 	out, err := format.Source(w.Bytes())
@@ -106,41 +184,7 @@ func writeConvertTextWithTagsCodeToBuffer(w *bytes.Buffer) {
 
 	t := template.Must(template.New("").Parse(convertTextWithTagsMarshalJSONCodeTemplate))
 
-	conversionFunctionsForTypes := map[string]string{
-		"Abstract": "getConvertedTextWithTags",
-		// Do not add AccessTermWithRole because it has a unique MarshalJSON method
-		// already defined which does relator code translation.
-		"AddressLine": "getConvertedTextWithTags",
-		"ArchRef":     "getConvertedTextWithTags",
-		"BibRef":      "getConvertedTextWithTags",
-		"ChronItem":   "getConvertedTextWithTags",
-		"Container":   "getConvertedTextWithTags",
-		"Creation":    "getConvertedTextWithTags",
-		// Do not add DAO because it requires custom marshaling.
-		"Date": "getConvertedTextWithTags",
-		// Do not add DID because it requires custom marshaling.
-		"Dimensions": "getConvertedTextWithTags",
-		"Event":      "getConvertedTextWithTags",
-		// Extent has custom marshaling requirements and is therefore not generated.
-		"Head": "getConvertedTextWithTags",
-		// Do not add IndexEntry because it requires custom marshaling.
-		"Item":         "getConvertedTextWithTags",
-		"LangMaterial": "getConvertedTextWithTags",
-		"LangUsage":    "getConvertedTextWithTags",
-		"LegalStatus":  "getConvertedTextWithTags",
-		"Num":          "getConvertedTextWithTags",
-		"P":            "getConvertedTextWithTags",
-		"PhysFacet":    "getConvertedTextWithTags",
-		// Do not add PhysDesc, whose MarshalJSON is created by generator
-		// writeOmitWhitespaceOnlyValueFieldsAndConvertTextWithTagsCodeToBuffer.
-		"PhysLoc":    "getConvertedTextWithTags",
-		"Repository": "getConvertedTextWithTags",
-		"Title":      "getConvertedTextWithTagsNoLBConversion",
-		// Do not add TitleProper because it requires custom marshaling.
-		// Do not add TitleStmt   because it requires custom marshaling.
-		"UnitDate":  "getConvertedTextWithTags",
-		"UnitTitle": "getConvertedTextWithTags",
-	}
+	conversionFunctionsForTypes := convertTextWithTagsConversionFunctionsForTypes
 
 	sortedTypes := make([]string, len(conversionFunctionsForTypes))
 	i := 0
@@ -174,9 +218,7 @@ func writeOmitWhitespaceOnlyValueFieldsAndConvertTextWithTagsCodeToBuffer(w *byt
 
 	t := template.Must(template.New("").Parse(omitWhitespaceOnlyValueFieldsAndConvertTextWithTagsMarshalJSONCodeTemplate))
 
-	conversionFunctionsForTypes := map[string]string{
-		"PhysDesc": "getConvertedTextWithTags",
-	}
+	conversionFunctionsForTypes := omitWhitespaceOnlyValueFieldsAndConvertTextWithTagsConversionFunctionsForTypes
 
 	sortedTypes := make([]string, len(conversionFunctionsForTypes))
 	i := 0
@@ -201,3 +243,39 @@ func writeOmitWhitespaceOnlyValueFieldsAndConvertTextWithTagsCodeToBuffer(w *byt
 		}
 	}
 }
+
+// writeValueMethodCodeToBuffer writes a method that processes the Value field,
+// e.g., Inline(), for every type with a generated MarshalJSON method, except
+// for the skipped types
+func writeValueMethodCodeToBuffer(w *bytes.Buffer, codeTemplate string, skippedTypes map[string]bool) {
+	type templateData struct {
+		TypeName string
+		VarName  string
+	}
+
+	t := template.Must(template.New("").Parse(codeTemplate))
+
+	var sortedTypes []string
+	for k := range convertTextWithTagsConversionFunctionsForTypes {
+		sortedTypes = append(sortedTypes, k)
+	}
+	for k := range omitWhitespaceOnlyValueFieldsAndConvertTextWithTagsConversionFunctionsForTypes {
+		sortedTypes = append(sortedTypes, k)
+	}
+	sort.Strings(sortedTypes)
+
+	for _, typeName := range sortedTypes {
+		if skippedTypes[typeName] {
+			continue
+		}
+		w.WriteString("\n\n")
+
+		err := t.Execute(w, templateData{
+			TypeName: typeName,
+			VarName:  strings.ToLower(typeName),
+		})
+		if err != nil {
+			panic(err)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package ead
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// SortKeyWidth is the minimum zero-padded width of each level of a component
+// SortKey.  All levels are widened to the number of digits of the largest
+// sibling count if it has more digits, e.g., to 6 for 100,000 siblings, so
+// that the keys of an EAD always sort lexically in document order.
+const SortKeyWidth = 5
+
+// InitComponentHierarchy sets the Parent and SortKey of every component.
+//
+// SortKey is a hierarchical sort key made of the 1-based position of the
+// component and each of its ancestors among their siblings, e.g.,
+// "00001.00002.00014" for the 14th child of the 2nd child of the 1st
+// top-level component.  Sorting the keys lexically produces document order.
+// The width of the levels is SortKeyWidth, unless there are more siblings
+// than that many digits can number, see SortKeyWidth.
+//
+// Call InitComponentHierarchy again after the component hierarchy is
+// modified, e.g., by InitPresentationComponents().
+func (e *EAD) InitComponentHierarchy() {
+	width := SortKeyWidth
+	if e.ArchDesc != nil && e.ArchDesc.DSC != nil {
+		maxSiblings := len(e.ArchDesc.DSC.C)
+		e.WalkComponents(func(c *C, info *WalkInfo) error {
+			if len(c.C) > maxSiblings {
+				maxSiblings = len(c.C)
+			}
+			return nil
+		})
+		if digits := len(strconv.Itoa(maxSiblings)); digits > width {
+			width = digits
+		}
+	}
+
+	e.WalkComponents(func(c *C, info *WalkInfo) error {
+		c.Parent = info.Parent()
+
+		position := fmt.Sprintf("%0*d", width, info.Index+1)
+		if c.Parent == nil {
+			c.SortKey = position
+		} else {
+			c.SortKey = c.Parent.SortKey + "." + position
+		}
+
+		return nil
+	})
+}
+
+// Ancestors returns the ancestors of the component, starting with the
+// top-level component.  Requires InitComponentHierarchy().
+func (c *C) Ancestors() []*C {
+	var ancestors []*C
+	for parent := c.Parent; parent != nil; parent = parent.Parent {
+		ancestors = append(ancestors, parent)
+	}
+
+	// reverse so that the top-level component is first
+	for i, j := 0, len(ancestors)-1; i < j; i, j = i+1, j-1 {
+		ancestors[i], ancestors[j] = ancestors[j], ancestors[i]
+	}
+
+	return ancestors
+}
+
+// Path returns the ancestors of the component followed by the component,
+// e.g., for rendering "Series I > Subseries 2 > Folder 14" breadcrumbs.
+// Requires InitComponentHierarchy().
+func (c *C) Path() []*C {
+	return append(c.Ancestors(), c)
+}
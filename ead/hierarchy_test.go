@@ -0,0 +1,119 @@
+package ead
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func formatComponentIDs(cs []*C) string {
+	var ids []string
+	for _, c := range cs {
+		ids = append(ids, string(c.ID))
+	}
+	return strings.Join(ids, " > ")
+}
+
+func TestInitComponentHierarchy(t *testing.T) {
+	t.Run("InitComponentHierarchy", func(t *testing.T) {
+		sut := getOmegaEAD(t)
+		sut.InitComponentHierarchy()
+
+		series := sut.ArchDesc.DSC.C[0]
+		deepest := series.C[0].C[0].C[0].C[0].C[0]
+
+		assertEqual(t, "aspace_71626d77bd977b19462b7319b0d4a5fb", string(deepest.ID), "Deepest component ID")
+		assertEqual(t,
+			"aspace_499449c48c751a22b7c222d3ce2c2879 > aspace_68fd22d28746c12f37e250728431c61d > aspace_f35efa0f6a068b57a2d396067e4f7427 > aspace_a8e8b321d84febb7aee747f54e624fc4 > aspace_bb018068fcbef8e42d90b29434d476d6",
+			formatComponentIDs(deepest.Ancestors()), "Ancestors()")
+		assertEqual(t,
+			formatComponentIDs(deepest.Ancestors())+" > aspace_71626d77bd977b19462b7319b0d4a5fb",
+			formatComponentIDs(deepest.Path()), "Path()")
+
+		if series.Parent != nil {
+			t.Errorf("Expected top-level component to have no parent")
+		}
+		if len(series.Ancestors()) != 0 {
+			t.Errorf("Expected top-level component to have no ancestors")
+		}
+		assertEqual(t, string(series.ID), formatComponentIDs(series.Path()), "Top-level Path()")
+		if series.C[1].Parent != series {
+			t.Errorf("Expected component parent to be the series")
+		}
+
+		assertEqual(t, "00001", series.SortKey, "Top-level SortKey")
+		assertEqual(t, "00001.00003", series.C[2].SortKey, "Third child SortKey")
+		assertEqual(t, "00001.00001.00001.00001.00001.00001", deepest.SortKey, "Deepest SortKey")
+		assertEqual(t, "00002.00008", sut.ArchDesc.DSC.C[1].C[7].SortKey, "Last component SortKey")
+
+		// sort keys sort in document order
+		var previous string
+		sut.WalkComponents(func(c *C, info *WalkInfo) error {
+			if c.SortKey <= previous {
+				t.Errorf("SortKey %q does not sort after %q", c.SortKey, previous)
+			}
+			previous = c.SortKey
+			return nil
+		})
+	})
+}
+
+func TestInitComponentHierarchySortKeyWidth(t *testing.T) {
+	t.Run("InitComponentHierarchy SortKey Width", func(t *testing.T) {
+		cs := make([]*C, 100000)
+		for i := range cs {
+			cs[i] = &C{}
+		}
+		cs[0].C = []*C{{}, {}}
+		sut := EAD{ArchDesc: &ArchDesc{DSC: &DSC{C: cs}}}
+		sut.InitComponentHierarchy()
+
+		assertEqual(t, "000001", cs[0].SortKey, "First SortKey")
+		assertEqual(t, "000001.000002", cs[0].C[1].SortKey, "Child SortKey")
+		assertEqual(t, "000010", cs[9].SortKey, "Tenth SortKey")
+		assertEqual(t, "100000", cs[99999].SortKey, "Last SortKey")
+
+		// sort keys sort in document order
+		var previous string
+		sut.WalkComponents(func(c *C, info *WalkInfo) error {
+			if c.SortKey <= previous {
+				t.Errorf("SortKey %q does not sort after %q", c.SortKey, previous)
+			}
+			previous = c.SortKey
+			return nil
+		})
+	})
+}
+
+func TestInitComponentHierarchyWithPresentationComponents(t *testing.T) {
+	t.Run("InitComponentHierarchy with Presentation Components", func(t *testing.T) {
+		sut := getPresentationComponentEAD(t, "pc-c-k-c.xml")
+		sut.InitPresentationComponents()
+		sut.InitComponentHierarchy()
+
+		sut.WalkComponents(func(c *C, info *WalkInfo) error {
+			if c.Parent != info.Parent() {
+				t.Errorf("Unexpected parent for component %s", c.ID)
+			}
+			if len(c.Ancestors()) != info.Depth-1 {
+				t.Errorf("Expected %d ancestors for component %s, got %d", info.Depth-1, c.ID, len(c.Ancestors()))
+			}
+			return nil
+		})
+	})
+}
+
+func TestJSONMarshalingWithComponentHierarchy(t *testing.T) {
+	var params iJSONTestParams
+
+	params.TestName = "JSON Marshaling with InitComponentHierarchy()"
+	params.EADFilePath = filepath.Join(omegaTestFixturePath, "Omega-EAD.xml")
+	params.JSONReferenceFilePath = filepath.Join(omegaTestFixturePath, "mos_2021-with-component-hierarchy.json")
+	params.JSONErrorFilePath = "./testdata/tmp/failing-marshal-with-component-hierarchy.json"
+
+	ead := getTestEAD(t, params.EADFilePath)
+	ead.InitComponentHierarchy()
+
+	params.PrePopulatedEAD = ead
+	runiJSONComparisonTest(t, &params)
+}
@@ -0,0 +1,291 @@
+package ead
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+)
+
+// Hugo content file (hJSON) generation.
+//
+// Each generated file is a Hugo content file whose front matter is a JSON
+// object, and whose body is empty.  The files are laid out as follows, where
+// <repoid> is PubInfo.RepoID and is omitted if it is not set:
+//
+//	<repoid>/<eadid>/_index.md           collection landing page
+//	<repoid>/<eadid>/contents/<id>.md    one page per top-level component
+//	<repoid>/<eadid>/all.md              all-in-one page
+//
+// The top-level components are the series, other-level, record group, and
+// presentation components created by InitPresentationComponents().
+
+const (
+	HugoCollectionLayout = "collection"
+	HugoComponentLayout  = "component"
+	HugoAllLayout        = "all"
+)
+
+// HugoContentFile is a generated Hugo content file.
+// Path is a slash-separated path relative to the Hugo content directory.
+type HugoContentFile struct {
+	Path string
+	Data []byte
+}
+
+// ContentWriter is the destination for generated Hugo content files.
+// name is a slash-separated path relative to the Hugo content directory.
+type ContentWriter interface {
+	WriteFile(name string, data []byte) error
+}
+
+// DirContentWriter writes Hugo content files to a directory,
+// creating subdirectories as needed
+type DirContentWriter struct {
+	Dir string
+}
+
+func NewDirContentWriter(dir string) *DirContentWriter {
+	return &DirContentWriter{Dir: dir}
+}
+
+func (w *DirContentWriter) WriteFile(name string, data []byte) error {
+	filePath := filepath.Join(w.Dir, filepath.FromSlash(name))
+
+	err := os.MkdirAll(filepath.Dir(filePath), 0755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filePath, data, 0644)
+}
+
+// HugoContentsEntry is a table of contents entry for a top-level component
+type HugoContentsEntry struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Level  string `json:"level,omitempty"`
+	Weight int    `json:"weight"`
+	URL    string `json:"url"`
+}
+
+type hugoCollectionPage struct {
+	Title     string              `json:"title"`
+	Layout    string              `json:"layout"`
+	EADID     string              `json:"eadid"`
+	RunInfo   RunInfo             `json:"runinfo"`
+	PubInfo   PubInfo             `json:"pubinfo"`
+	Donors    Donors              `json:"donors,omitempty"`
+	EADHeader EADHeader           `json:"eadheader"`
+	ArchDesc  *ArchDesc           `json:"archdesc,omitempty"`
+	Contents  []HugoContentsEntry `json:"contents,omitempty"`
+}
+
+type hugoComponentPage struct {
+	Title           string             `json:"title"`
+	Layout          string             `json:"layout"`
+	EADID           string             `json:"eadid"`
+	CollectionTitle string             `json:"collectiontitle"`
+	Weight          int                `json:"weight"`
+	Previous        *HugoContentsEntry `json:"previous,omitempty"`
+	Next            *HugoContentsEntry `json:"next,omitempty"`
+	Component       *C                 `json:"component"`
+}
+
+type hugoAllPage struct {
+	Title           string `json:"title"`
+	Layout          string `json:"layout"`
+	EADID           string `json:"eadid"`
+	CollectionTitle string `json:"collectiontitle"`
+	DSC             *DSC   `json:"dsc,omitempty"`
+}
+
+// GenerateHugoContent generates the Hugo content files for the EAD.  The
+// component pages are generated from the top-level components with the
+// presentation components added, see InitPresentationComponents(), but the
+// EAD itself is not modified, so GenerateHugoContent can be called more than
+// once.
+func (e *EAD) GenerateHugoContent() ([]HugoContentFile, error) {
+	if e.ArchDesc == nil {
+		return nil, fmt.Errorf("unable to generate Hugo content: missing <archdesc> element")
+	}
+
+	eadid := e.EADID()
+	if !hugoPathSegmentRegexp.MatchString(eadid) {
+		return nil, fmt.Errorf("unable to generate Hugo content: invalid EADID %q", eadid)
+	}
+
+	root := eadid
+	if e.RepoID() != "" {
+		if !hugoPathSegmentRegexp.MatchString(e.RepoID()) {
+			return nil, fmt.Errorf("unable to generate Hugo content: invalid repository identifier %q", e.RepoID())
+		}
+		root = path.Join(e.RepoID(), eadid)
+	}
+
+	var collectionTitle []byte
+	if e.ArchDesc.DID.UnitTitle != nil {
+		var err error
+		collectionTitle, err = e.ArchDesc.DID.UnitTitle.htmlRenderer().convertTextWithTags(e.GuideTitle())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var cs []*C
+	var dsc *DSC
+	if e.ArchDesc.DSC != nil {
+		cs = addPresentationComponents(&e.ArchDesc.DSC.C)
+		dscWithPresentationComponents := *e.ArchDesc.DSC
+		dscWithPresentationComponents.C = cs
+		dsc = &dscWithPresentationComponents
+	}
+
+	contents, err := hugoContents(root, cs)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []HugoContentFile
+
+	// the landing page does not include the <dsc>: the components are
+	// on the component pages and the all-in-one page
+	archDesc := *e.ArchDesc
+	archDesc.DSC = nil
+
+	file, err := makeHugoContentFile(path.Join(root, "_index.md"), &hugoCollectionPage{
+		Title:     string(collectionTitle),
+		Layout:    HugoCollectionLayout,
+		EADID:     eadid,
+		RunInfo:   e.RunInfo,
+		PubInfo:   e.PubInfo,
+		Donors:    e.Donors,
+		EADHeader: e.EADHeader,
+		ArchDesc:  &archDesc,
+		Contents:  contents,
+	})
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, file)
+
+	for i, c := range cs {
+		page := &hugoComponentPage{
+			Title:           contents[i].Title,
+			Layout:          HugoComponentLayout,
+			EADID:           eadid,
+			CollectionTitle: string(collectionTitle),
+			Weight:          contents[i].Weight,
+			Component:       c,
+		}
+		if i > 0 {
+			page.Previous = &contents[i-1]
+		}
+		if i < len(contents)-1 {
+			page.Next = &contents[i+1]
+		}
+
+		file, err := makeHugoContentFile(hugoComponentPath(root, contents[i].ID), page)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	file, err = makeHugoContentFile(path.Join(root, "all.md"), &hugoAllPage{
+		Title:           string(collectionTitle),
+		Layout:          HugoAllLayout,
+		EADID:           eadid,
+		CollectionTitle: string(collectionTitle),
+		DSC:             dsc,
+	})
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, file)
+
+	return files, nil
+}
+
+// WriteHugoContent generates the Hugo content files for the EAD and writes them to w
+func (e *EAD) WriteHugoContent(w ContentWriter) error {
+	files, err := e.GenerateHugoContent()
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		err = w.WriteFile(file.Path, file.Data)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// component IDs are used as file names, so they are restricted to
+// characters that are safe in paths and URLs
+var hugoPathSegmentRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+func hugoContents(root string, cs []*C) ([]HugoContentsEntry, error) {
+	var contents []HugoContentsEntry
+
+	// the component IDs that are used as-is, so that the positional
+	// fallback IDs cannot collide with them
+	var ids = make([]string, len(cs))
+	var seen = map[string]bool{}
+	for i, c := range cs {
+		id := string(c.ID)
+		if hugoPathSegmentRegexp.MatchString(id) && !seen[id] {
+			ids[i] = id
+			seen[id] = true
+		}
+	}
+
+	for i, c := range cs {
+		id := ids[i]
+		if id == "" {
+			// fall back to a positional ID for missing, unsafe, or duplicate IDs
+			id = fmt.Sprintf("c%03d", i+1)
+			for n := 2; seen[id]; n++ {
+				id = fmt.Sprintf("c%03d-%d", i+1, n)
+			}
+			seen[id] = true
+		}
+
+		var title []byte
+		if c.DID.UnitTitle != nil {
+			var err error
+			title, err = c.DID.UnitTitle.htmlRenderer().convertTextWithTags(c.DID.UnitTitle.Value)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		contents = append(contents, HugoContentsEntry{
+			ID:     id,
+			Title:  string(title),
+			Level:  string(c.Level),
+			Weight: i + 1,
+			URL:    "/" + path.Join(root, "contents", id) + "/",
+		})
+	}
+
+	return contents, nil
+}
+
+func hugoComponentPath(root string, id string) string {
+	return path.Join(root, "contents", id+".md")
+}
+
+func makeHugoContentFile(name string, frontMatter interface{}) (HugoContentFile, error) {
+	jsonData, err := json.MarshalIndent(frontMatter, "", "    ")
+	if err != nil {
+		return HugoContentFile{}, err
+	}
+
+	return HugoContentFile{Path: name, Data: append(jsonData, '\n')}, nil
+}
@@ -0,0 +1,205 @@
+package ead
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+type hugoContentTestParams struct {
+	TestName         string
+	EAD              *EAD
+	ReferenceDirPath string
+	ErrorDirPath     string
+}
+
+var hugoTestFixturePath string = filepath.Join(testFixturePath, "hugo")
+
+// mapContentWriter is a ContentWriter that collects the files in memory
+type mapContentWriter map[string][]byte
+
+func (w mapContentWriter) WriteFile(name string, data []byte) error {
+	if _, ok := w[name]; ok {
+		return fmt.Errorf("%s written more than once", name)
+	}
+	w[name] = data
+	return nil
+}
+
+func runHugoContentComparisonTest(t *testing.T, params *hugoContentTestParams) {
+	t.Run(params.TestName, func(t *testing.T) {
+		got := mapContentWriter{}
+		err := params.EAD.WriteHugoContent(got)
+		failOnError(t, err, "Unexpected error generating Hugo content")
+
+		var referenceFiles []string
+		err = filepath.WalkDir(params.ReferenceDirPath, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			relativePath, err := filepath.Rel(params.ReferenceDirPath, path)
+			if err != nil {
+				return err
+			}
+			referenceFiles = append(referenceFiles, filepath.ToSlash(relativePath))
+			return nil
+		})
+		failOnError(t, err, "Unexpected error reading reference directory")
+
+		var gotFiles []string
+		for name := range got {
+			gotFiles = append(gotFiles, name)
+		}
+		sort.Strings(gotFiles)
+		assertEqual(t, strings.Join(referenceFiles, "\n"), strings.Join(gotFiles, "\n"), "Hugo content file list")
+
+		for _, name := range gotFiles {
+			referenceFile := filepath.Join(params.ReferenceDirPath, filepath.FromSlash(name))
+			referenceFileContents, err := os.ReadFile(referenceFile)
+			if err != nil && !os.IsNotExist(err) {
+				failOnError(t, err, "Unexpected error reading reference file")
+			}
+
+			if !bytes.Equal(referenceFileContents, got[name]) {
+				errorFile := filepath.Join(params.ErrorDirPath, filepath.FromSlash(name))
+				err = NewDirContentWriter(params.ErrorDirPath).WriteFile(name, got[name])
+				failOnError(t, err, fmt.Sprintf("Unexpected error writing %s", errorFile))
+
+				t.Errorf("Hugo content file does not match reference file.\ndiff %s %s", errorFile, referenceFile)
+			}
+		}
+	})
+}
+
+func TestHugoContentGeneration(t *testing.T) {
+	var params hugoContentTestParams
+
+	sut := getOmegaEAD(t)
+	sut.PubInfo.SetPubInfo("cdf80c84-2d8a-4c0d-ac48-3b5dd3ac2a6e", "tamwag")
+
+	params.TestName = "Hugo Content Generation"
+	params.EAD = &sut
+	params.ReferenceDirPath = filepath.Join(hugoTestFixturePath, "omega")
+	params.ErrorDirPath = "./testdata/tmp/failing-hugo-content-omega"
+
+	runHugoContentComparisonTest(t, &params)
+}
+
+func TestHugoContentGenerationWithPresentationComponents(t *testing.T) {
+	var params hugoContentTestParams
+
+	sut := getPresentationComponentEAD(t, "pc-c-k-c.xml")
+
+	params.TestName = "Hugo Content Generation With Presentation Components"
+	params.EAD = &sut
+	params.ReferenceDirPath = filepath.Join(hugoTestFixturePath, "pc-c-k-c")
+	params.ErrorDirPath = "./testdata/tmp/failing-hugo-content-pc-c-k-c"
+
+	runHugoContentComparisonTest(t, &params)
+}
+
+func TestHugoContentGenerationNoComponents(t *testing.T) {
+	t.Run("Hugo Content Generation No Components", func(t *testing.T) {
+		sut := getPresentationComponentEAD(t, "pc-no-components.xml")
+
+		files, err := sut.GenerateHugoContent()
+		failOnError(t, err, "Unexpected error generating Hugo content")
+
+		var got []string
+		for _, file := range files {
+			got = append(got, file.Path)
+		}
+		assertEqual(t, "mos_2021/_index.md\nmos_2021/all.md", strings.Join(got, "\n"), "Hugo content file list")
+	})
+}
+
+func TestHugoContentGenerationIsRepeatable(t *testing.T) {
+	t.Run("Hugo Content Generation Is Repeatable", func(t *testing.T) {
+		sut := getPresentationComponentEAD(t, "pc-c-k-c.xml")
+		cs := append([]*C{}, sut.ArchDesc.DSC.C...)
+
+		first, err := sut.GenerateHugoContent()
+		failOnError(t, err, "Unexpected error generating Hugo content")
+		second, err := sut.GenerateHugoContent()
+		failOnError(t, err, "Unexpected error generating Hugo content a second time")
+
+		assertEqual(t, fmt.Sprint(len(first)), fmt.Sprint(len(second)), "Hugo content file count")
+		for i := range first {
+			if first[i].Path != second[i].Path || !bytes.Equal(first[i].Data, second[i].Data) {
+				t.Errorf("Hugo content file %s differs from the first generation", second[i].Path)
+			}
+		}
+
+		assertEqual(t, fmt.Sprint(len(cs)), fmt.Sprint(len(sut.ArchDesc.DSC.C)), "Top-level component count")
+		for i := range cs {
+			if cs[i] != sut.ArchDesc.DSC.C[i] {
+				t.Errorf("Top-level component %d was modified", i)
+			}
+		}
+	})
+}
+
+func TestHugoContentsPositionalIDs(t *testing.T) {
+	t.Run("Hugo Contents Positional IDs", func(t *testing.T) {
+		cs := []*C{
+			{Level: "series"},
+			{ID: "c001", Level: "series"},
+			{ID: "c001", Level: "series"},
+			{ID: "../c004", Level: "series"},
+		}
+
+		contents, err := hugoContents("mos_2021", cs)
+		failOnError(t, err, "Unexpected error generating Hugo contents")
+
+		var got []string
+		for _, entry := range contents {
+			got = append(got, entry.ID)
+		}
+		assertEqual(t, "c001-2 c001 c003 c004", strings.Join(got, " "), "Hugo contents IDs")
+	})
+}
+
+func TestHugoContentGenerationErrors(t *testing.T) {
+	t.Run("Hugo Content Generation Errors", func(t *testing.T) {
+		sut := getOmegaEAD(t)
+		sut.EADHeader.EADID.Value = "../mos_2021"
+		_, err := sut.GenerateHugoContent()
+		if err == nil {
+			t.Errorf("Expected an error for an EADID that is not a valid path segment")
+		}
+
+		sut = getOmegaEAD(t)
+		sut.PubInfo.SetPubInfo("", "tam/wag")
+		_, err = sut.GenerateHugoContent()
+		if err == nil {
+			t.Errorf("Expected an error for a repository identifier that is not a valid path segment")
+		}
+
+		sut = EAD{}
+		sut.EADHeader.EADID.Value = "mos_2021"
+		_, err = sut.GenerateHugoContent()
+		if err == nil {
+			t.Errorf("Expected an error for a missing <archdesc>")
+		}
+	})
+}
+
+func TestDirContentWriter(t *testing.T) {
+	t.Run("DirContentWriter", func(t *testing.T) {
+		dir := t.TempDir()
+		sut := getPresentationComponentEAD(t, "pc-c-k-c.xml")
+
+		err := sut.WriteHugoContent(NewDirContentWriter(dir))
+		failOnError(t, err, "Unexpected error writing Hugo content")
+
+		for _, name := range []string{"_index.md", "all.md", filepath.Join("contents", "items001.md")} {
+			_, err := os.Stat(filepath.Join(dir, "mos_2021", name))
+			failOnError(t, err, "Missing Hugo content file")
+		}
+	})
+}
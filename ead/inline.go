@@ -0,0 +1,262 @@
+package ead
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// InlineNodeType is the type of an InlineNode
+type InlineNodeType string
+
+const (
+	InlineTextNode    InlineNodeType = "text"
+	InlineElementNode InlineNodeType = "element"
+	InlineCommentNode InlineNodeType = "comment"
+)
+
+// InlineNode is a node of the inline mixed content tree of an element,
+// e.g., the text, <emph>, <title>, <persname>, <extref>, <lb/>, and <date>
+// content of a <p>.
+type InlineNode struct {
+	Type InlineNodeType `json:"type"`
+
+	// Name is the element name, including the namespace prefix, if any,
+	// e.g., "emph".  Name is empty for text and comment nodes.
+	Name string `json:"name,omitempty"`
+
+	// Attr holds the element attributes in document order
+	Attr []xml.Attr `json:"attr,omitempty"`
+
+	// Text is the unescaped character data of a text node, or the text
+	// of a comment node
+	Text string `json:"text,omitempty"`
+
+	Children InlineContent `json:"children,omitempty"`
+}
+
+// InlineContent is a sequence of inline nodes in document order
+type InlineContent []*InlineNode
+
+// inlineValue keeps the InlineContent of the Value of a type, which is parsed
+// once when the type is decoded, see the generated Inline() methods
+type inlineValue struct {
+	// source is the Value that the content was parsed from
+	source  string
+	content InlineContent
+	err     error
+	parsed  bool
+}
+
+func (iv *inlineValue) parse(value string) {
+	iv.source = value
+	iv.content, iv.err = ParseInlineContent(value)
+	iv.parsed = true
+}
+
+// inline returns the parsed content of the value.  A value that was not
+// decoded, e.g., the Value of a type created or changed in Go, is parsed on
+// each call.
+func (iv *inlineValue) inline(value string) (InlineContent, error) {
+	if iv.parsed && iv.source == value {
+		return iv.content, iv.err
+	}
+	return ParseInlineContent(value)
+}
+
+// ParseInlineContent decodes the mixed content of an element, i.e., the
+// innerxml Value of most types, into an InlineContent tree.  Processing
+// instructions and directives are dropped.
+//
+// The types with a generated Inline() method parse their Value once when
+// they are decoded, and keep the tree for their MarshalJSON(), PlainText(),
+// and Markdown() methods.
+func ParseInlineContent(value string) (InlineContent, error) {
+	decoder := xml.NewDecoder(strings.NewReader(value))
+
+	// root collects the top-level nodes
+	root := &InlineNode{}
+	stack := []*InlineNode{root}
+	for {
+		// RawToken preserves the namespace prefixes for rendering back to XML
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		parent := stack[len(stack)-1]
+		switch token := token.(type) {
+		case xml.StartElement:
+			node := &InlineNode{
+				Type: InlineElementNode,
+				Name: qualifiedXMLName(token.Name),
+				Attr: append([]xml.Attr(nil), token.Attr...),
+			}
+			parent.Children = append(parent.Children, node)
+			stack = append(stack, node)
+
+		case xml.EndElement:
+			name := qualifiedXMLName(token.Name)
+			if len(stack) == 1 {
+				return nil, fmt.Errorf("unexpected end element </%s>", name)
+			}
+			if parent.Name != name {
+				return nil, fmt.Errorf("element <%s> closed by </%s>", parent.Name, name)
+			}
+			stack = stack[:len(stack)-1]
+
+		case xml.CharData:
+			children := parent.Children
+			// merge adjacent character data, e.g., text followed by a CDATA section
+			if len(children) > 0 && children[len(children)-1].Type == InlineTextNode {
+				children[len(children)-1].Text += string(token)
+			} else {
+				parent.Children = append(children, &InlineNode{Type: InlineTextNode, Text: string(token)})
+			}
+
+		case xml.Comment:
+			parent.Children = append(parent.Children, &InlineNode{Type: InlineCommentNode, Text: string(token)})
+		}
+	}
+
+	if len(stack) > 1 {
+		return nil, fmt.Errorf("unclosed element <%s>", stack[len(stack)-1].Name)
+	}
+
+	return root.Children, nil
+}
+
+func qualifiedXMLName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+// LocalName returns the element name without the namespace prefix
+func (n *InlineNode) LocalName() string {
+	if i := strings.IndexByte(n.Name, ':'); i >= 0 {
+		return n.Name[i+1:]
+	}
+	return n.Name
+}
+
+// Attribute returns the value of the first attribute with the local name,
+// e.g., "href" for "xlink:href", or "" if there is no such attribute
+func (n *InlineNode) Attribute(localName string) string {
+	for _, attr := range n.Attr {
+		if attr.Name.Local == localName {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// Elements returns the elements with the local name, e.g., "persname",
+// at any depth, in document order
+func (ic InlineContent) Elements(localName string) []*InlineNode {
+	var elements []*InlineNode
+	for _, node := range ic {
+		if node.Type != InlineElementNode {
+			continue
+		}
+		if node.LocalName() == localName {
+			elements = append(elements, node)
+		}
+		elements = append(elements, node.Children.Elements(localName)...)
+	}
+	return elements
+}
+
+// HTML renders the inline content as HTML with the default Renderer, e.g.,
+// <emph render="italic"> as <span class="ead-emph ead-emph-italic">, and
+// <lb/> as <br>.  This is the rendering of the iJSON "value" fields, unless
+// the EAD has a Renderer, see EAD.SetRenderer().
+//
+// The rendering is safe to include in a web page: character data and
+// attribute values are escaped, and the href of links is omitted unless
+// it is a relative URL or its scheme is in SafeURLSchemes.
+func (ic InlineContent) HTML() string {
+	return defaultRenderer.HTML(ic)
+}
+
+// HTMLNoLBConversion renders the inline content as HTML with the default
+// Renderer, but renders <lb/> like other elements instead of as <br>
+func (ic InlineContent) HTMLNoLBConversion() string {
+	return defaultRenderer.HTMLNoLBConversion(ic)
+}
+
+// quotes do not need to be escaped in character data
+var htmlTextEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func escapeHTMLText(s string) string {
+	return htmlTextEscaper.Replace(s)
+}
+
+// SafeURLSchemes are the URL schemes allowed in the href of rendered links
+var SafeURLSchemes = map[string]bool{
+	"http":   true,
+	"https":  true,
+	"mailto": true,
+	"ftp":    true,
+}
+
+// IsSafeURL returns true if the URL is relative, or if its scheme is in
+// SafeURLSchemes, e.g., false for "javascript:alert(1)"
+func IsSafeURL(href string) bool {
+	// browsers ignore whitespace and control characters in URLs,
+	// e.g., "java\tscript:alert(1)"
+	stripped := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, href)
+
+	i := strings.IndexAny(stripped, ":/?#")
+	if i < 0 || stripped[i] != ':' {
+		// relative URL
+		return true
+	}
+
+	return SafeURLSchemes[strings.ToLower(stripped[:i])]
+}
+
+// XML renders the inline content back to XML.  The result is equivalent to
+// the parsed value: empty elements are rendered as self-closing tags, and
+// character data is re-escaped.
+func (ic InlineContent) XML() string {
+	var sb strings.Builder
+	writeInlineXML(&sb, ic)
+	return sb.String()
+}
+
+var inlineXMLTextEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+var inlineXMLAttrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;",
+	"\t", "&#x9;", "\n", "&#xA;", "\r", "&#xD;")
+
+func writeInlineXML(sb *strings.Builder, ic InlineContent) {
+	for _, node := range ic {
+		switch node.Type {
+		case InlineTextNode:
+			sb.WriteString(inlineXMLTextEscaper.Replace(node.Text))
+		case InlineCommentNode:
+			sb.WriteString("<!--" + node.Text + "-->")
+		case InlineElementNode:
+			sb.WriteString("<" + node.Name)
+			for _, attr := range node.Attr {
+				sb.WriteString(fmt.Sprintf(` %s="%s"`, qualifiedXMLName(attr.Name), inlineXMLAttrEscaper.Replace(attr.Value)))
+			}
+			if len(node.Children) == 0 {
+				sb.WriteString("/>")
+				continue
+			}
+			sb.WriteString(">")
+			writeInlineXML(sb, node.Children)
+			sb.WriteString("</" + node.Name + ">")
+		}
+	}
+}
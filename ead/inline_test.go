@@ -0,0 +1,255 @@
+package ead
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const inlineTestValue = `Letters from <persname role="aut">Jane <emph render="italic">Doe</emph></persname>,<lb/>
+	regarding <title render="doublequote">Tom &amp; Jerry</title> and <extref xlink:href="https://example.org/?a=1&amp;b=2" xlink:show="new">the website</extref>
+	<!-- a comment --><extent unit="linear feet">2.5</extent> <date normal="1920">1920</date>`
+
+func formatInlineNodes(ic InlineContent) string {
+	var s []string
+	for _, node := range ic {
+		switch node.Type {
+		case InlineTextNode:
+			s = append(s, fmt.Sprintf("%q", node.Text))
+		case InlineCommentNode:
+			s = append(s, "comment")
+		case InlineElementNode:
+			s = append(s, node.Name+"("+formatInlineNodes(node.Children)+")")
+		}
+	}
+	return strings.Join(s, " ")
+}
+
+func TestParseInlineContent(t *testing.T) {
+	t.Run("ParseInlineContent", func(t *testing.T) {
+		content, err := ParseInlineContent(`a <emph>b <title>c</title></emph><lb/>d<![CDATA[ & e]]>`)
+		failOnError(t, err, "Unexpected error parsing inline content")
+		assertEqual(t, `"a " emph("b " title("c")) lb() "d & e"`, formatInlineNodes(content), "Inline content tree")
+
+		content, err = ParseInlineContent(inlineTestValue)
+		failOnError(t, err, "Unexpected error parsing inline content")
+
+		persNames := content.Elements("persname")
+		if len(persNames) != 1 {
+			t.Fatalf("Expected 1 <persname>, got %d", len(persNames))
+		}
+		assertEqual(t, "aut", persNames[0].Attribute("role"), "persname @role")
+		assertEqual(t, "Jane Doe", persNames[0].Children.PlainText(), "persname plain text")
+
+		var names []string
+		for _, element := range content.Elements("emph") {
+			names = append(names, element.Children.PlainText())
+		}
+		assertEqual(t, "Doe", strings.Join(names, ","), "Nested elements")
+
+		extRefs := content.Elements("extref")
+		assertEqual(t, "xlink:href", qualifiedXMLName(extRefs[0].Attr[0].Name), "Namespace prefix")
+		assertEqual(t, "https://example.org/?a=1&b=2", extRefs[0].Attribute("href"), "extref @href")
+	})
+
+	t.Run("ParseInlineContent Errors", func(t *testing.T) {
+		for _, value := range []string{"<emph>unclosed", "unopened</emph>", "<emph>a</title>", "a &nbsp; b"} {
+			_, err := ParseInlineContent(value)
+			if err == nil {
+				t.Errorf("Expected an error parsing %q", value)
+			}
+		}
+	})
+}
+
+func TestInlineContentRendering(t *testing.T) {
+	content, err := ParseInlineContent(inlineTestValue)
+	failOnError(t, err, "Unexpected error parsing inline content")
+
+	t.Run("InlineContent HTML", func(t *testing.T) {
+		want := `Letters from <span class="ead-persname">Jane <span class="ead-emph ead-emph-italic">Doe</span></span>,<br> regarding <span class="ead-title ead-emph-doublequote">Tom &amp; Jerry</span> and <a class="ead-extref" href="https://example.org/?a=1&amp;b=2" target="new">the website</a> <span class="ead-extent">2.5 linear feet</span> <span class="ead-date">1920</span>`
+		assertEqual(t, want, content.HTML(), "HTML()")
+
+		result, err := getConvertedTextWithTags(inlineTestValue)
+		failOnError(t, err, "Unexpected error converting text with tags")
+		assertEqual(t, want, string(result), "getConvertedTextWithTags()")
+
+		assertContains(t, content.HTMLNoLBConversion(), `,<span class="ead-lb"></span> regarding`, "HTMLNoLBConversion()")
+	})
+
+	t.Run("InlineContent PlainText", func(t *testing.T) {
+		assertEqual(t, "Letters from Jane Doe,\nregarding Tom & Jerry and the website [https://example.org/?a=1&b=2] 2.5 linear feet 1920", content.PlainText(), "PlainText()")
+	})
+
+	t.Run("InlineContent XML", func(t *testing.T) {
+		want := `Letters from <persname role="aut">Jane <emph render="italic">Doe</emph></persname>,<lb/>
+	regarding <title render="doublequote">Tom &amp; Jerry</title> and <extref xlink:href="https://example.org/?a=1&amp;b=2" xlink:show="new">the website</extref>
+	<!-- a comment --><extent unit="linear feet">2.5</extent> <date normal="1920">1920</date>`
+		assertEqual(t, want, content.XML(), "XML()")
+	})
+}
+
+func TestInlineContentRoundTrip(t *testing.T) {
+	t.Run("InlineContent Round Trip", func(t *testing.T) {
+		sut := getOmegaEAD(t)
+
+		var values []string
+		for _, scopeContent := range sut.ArchDesc.ScopeContent {
+			for _, child := range scopeContent.Children {
+				if p, ok := child.Value.(*P); ok {
+					values = append(values, p.Value)
+				}
+			}
+		}
+		sut.WalkComponents(func(c *C, info *WalkInfo) error {
+			if c.DID.UnitTitle != nil {
+				values = append(values, c.DID.UnitTitle.Value)
+			}
+			return nil
+		})
+		if len(values) == 0 {
+			t.Fatalf("Expected values to round trip")
+		}
+
+		for _, value := range values {
+			content, err := ParseInlineContent(value)
+			failOnError(t, err, "Unexpected error parsing inline content")
+
+			roundTripped, err := ParseInlineContent(content.XML())
+			failOnError(t, err, "Unexpected error parsing round-tripped inline content")
+
+			assertEqual(t, content.XML(), roundTripped.XML(), "Round-tripped XML")
+			assertEqual(t, content.HTML(), roundTripped.HTML(), "Round-tripped HTML")
+		}
+	})
+
+	t.Run("Generated Inline()", func(t *testing.T) {
+		p := P{Value: `See <title render="italic">Series I</title>.`}
+		content, err := p.Inline()
+		failOnError(t, err, "Unexpected error parsing inline content")
+		assertEqual(t, "Series I", content.Elements("title")[0].Children.PlainText(), "P.Inline()")
+	})
+
+	t.Run("Generated Inline() Parsed Once", func(t *testing.T) {
+		var p P
+		err := xml.Unmarshal([]byte(`<p>See <title render="italic">Series I</title>.</p>`), &p)
+		failOnError(t, err, "Unexpected error unmarshaling <p>")
+
+		// the decoded tree is returned instead of parsing the Value again
+		content, err := p.Inline()
+		failOnError(t, err, "Unexpected error parsing inline content")
+		again, err := p.Inline()
+		failOnError(t, err, "Unexpected error parsing inline content")
+		if len(content) == 0 || &content[0] != &again[0] {
+			t.Errorf("Expected P.Inline() to return the tree parsed by UnmarshalXML")
+		}
+
+		// a changed Value is parsed again
+		p.Value = "See <emph>Series II</emph>."
+		content, err = p.Inline()
+		failOnError(t, err, "Unexpected error parsing inline content")
+		assertEqual(t, "See Series II.", content.PlainText(), "P.Inline() of a changed Value")
+		jsonData, err := json.Marshal(&p)
+		failOnError(t, err, "Unexpected error marshaling <p>")
+		assertContains(t, string(jsonData), "Series II", "P.MarshalJSON() of a changed Value")
+	})
+}
+
+func TestInlineContentHTMLSanitization(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"Escaped Markup Stays Escaped", `&lt;script&gt;alert(1)&lt;/script&gt;`, `&lt;script&gt;alert(1)&lt;/script&gt;`},
+		{"Escaped Markup in CDATA", `<![CDATA[<img src=x onerror=alert(1)>]]>`, `&lt;img src=x onerror=alert(1)&gt;`},
+		{"JavaScript URL", `<extref href="javascript:alert(1)">x</extref>`, `<a class="ead-extref" target="">x</a>`},
+		{"JavaScript URL Uppercase", `<extref href="JavaScript:alert(1)">x</extref>`, `<a class="ead-extref" target="">x</a>`},
+		{"JavaScript URL Whitespace", `<extref href=" java&#9;script:alert(1)">x</extref>`, `<a class="ead-extref" target="">x</a>`},
+		{"Data URL", `<ref href="data:text/html;base64,PHNjcmlwdD4=">x</ref>`, `<a class="ead-ref" target="">x</a>`},
+		{"VBScript URL", `<extref href="vbscript:msgbox(1)">x</extref>`, `<a class="ead-extref" target="">x</a>`},
+		{"Relative URL", `<ref href="#aspace_ref1">x</ref>`, `<a class="ead-ref" href="#aspace_ref1" target="">x</a>`},
+		{"Mailto URL", `<extref href="mailto:archives@example.org">x</extref>`, `<a class="ead-extref" href="mailto:archives@example.org" target="">x</a>`},
+		{"Quote in HRef", `<extref href='https://example.org/" onmouseover="alert(1)'>x</extref>`,
+			`<a class="ead-extref" href="https://example.org/&#34; onmouseover=&#34;alert(1)" target="">x</a>`},
+		{"Quote in Show", `<extref href="https://example.org" show='new" onclick="alert(1)'>x</extref>`,
+			`<a class="ead-extref" href="https://example.org" target="new&#34; onclick=&#34;alert(1)">x</a>`},
+		{"Quote in Render", `<emph render='italic" onclick="alert(1)'>x</emph>`,
+			`<span class="ead-emph ead-emph-italic&#34; onclick=&#34;alert(1)">x</span>`},
+		{"Quote in Title Render", `<title render='"&gt;&lt;script&gt;'>x</title>`,
+			`<span class="ead-title ead-emph-&#34;&gt;&lt;script&gt;">x</span>`},
+		{"Markup in Unit", `<extent unit="&lt;b&gt;boxes&lt;/b&gt;">2</extent>`, `<span class="ead-extent">2 &lt;b&gt;boxes&lt;/b&gt;</span>`},
+	}
+
+	for _, tc := range testCases {
+		t.Run("InlineContent HTML Sanitization "+tc.name, func(t *testing.T) {
+			content, err := ParseInlineContent(tc.value)
+			failOnError(t, err, "Unexpected error parsing inline content")
+			assertEqual(t, tc.want, content.HTML(), "HTML()")
+		})
+	}
+}
+
+func TestIsSafeURL(t *testing.T) {
+	testCases := []struct {
+		href string
+		want bool
+	}{
+		{"https://example.org", true},
+		{"HTTP://example.org", true},
+		{"mailto:archives@example.org", true},
+		{"ftp://example.org/file.txt", true},
+		{"", true},
+		{"#aspace_ref1", true},
+		{"/search?q=a:b", true},
+		{"path/to:file", true},
+		{"javascript:alert(1)", false},
+		{"JAVASCRIPT:alert(1)", false},
+		{"java\tscript:alert(1)", false},
+		{"\x01javascript:alert(1)", false},
+		{" javascript:alert(1)", false},
+		{"data:text/html,<script>alert(1)</script>", false},
+		{"vbscript:msgbox(1)", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("IsSafeURL %q", tc.href), func(t *testing.T) {
+			if got := IsSafeURL(tc.href); got != tc.want {
+				t.Errorf("IsSafeURL(%q) = %t, want %t", tc.href, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestStructuredURLSanitization(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value string
+		v     interface{}
+		want  string
+	}{
+		{"P ExtRef", `<p>See <extref href="javascript:alert(1)">x</extref> and <extref href="https://example.org">y</extref></p>`, &P{},
+			`{"value":"See \u003ca class=\"ead-extref\" target=\"\"\u003ex\u003c/a\u003e and ` +
+				`\u003ca class=\"ead-extref\" href=\"https://example.org\" target=\"\"\u003ey\u003c/a\u003e",` +
+				`"extref":[{"href":""},{"href":"https://example.org"}]}`},
+		{"AddressLine ExtPtr", `<addressline><extptr href="JAVASCRIPT:alert(1)"/></addressline>`, &AddressLine{},
+			`{"value":"\u003cspan class=\"ead-extptr\"\u003e\u003c/span\u003e","extptr":[{"href":""}]}`},
+		{"DAO", `<dao href=" javascript:alert(1)" title="x"/>`, &DAO{},
+			`{"href":"","title":"x","daodesc":{}}`},
+		{"DAOGrp DAOLoc", `<daogrp><daoloc href="java&#x9;script:alert(1)"/><daoloc href="https://example.org/a.jpg"/></daogrp>`, &DAOGrp{},
+			`{"daodesc":{},"daoloc":[{"href":""},{"href":"https://example.org/a.jpg"}]}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run("Structured URL Sanitization "+tc.name, func(t *testing.T) {
+			err := xml.Unmarshal([]byte(tc.value), tc.v)
+			failOnError(t, err, "Unexpected error unmarshaling XML")
+
+			got, err := json.Marshal(tc.v)
+			failOnError(t, err, "Unexpected error marshaling JSON")
+			assertEqual(t, tc.want, string(got), "JSON")
+		})
+	}
+}
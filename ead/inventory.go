@@ -0,0 +1,242 @@
+package ead
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// ContainerNode is a <container> in a container hierarchy reconstructed from
+// the @id and @parent attributes, e.g., box -> folder -> item
+type ContainerNode struct {
+	Container *Container
+	Children  []*ContainerNode
+}
+
+// Type returns the cleaned up container @type, e.g., "Box"
+func (n *ContainerNode) Type() string {
+	return n.Container.Type.String()
+}
+
+// Indicator returns the cleaned up container value, e.g., "14"
+func (n *ContainerNode) Indicator() string {
+	return cleanupWhitespace(n.Container.Value)
+}
+
+// ContainerHierarchies reconstructs the container hierarchies of the <did>.
+//
+// A container whose @parent attribute matches the @id of another container in
+// the same <did> is a child of that container.  All other containers are root
+// containers.  The root containers, and the children of each container, are
+// returned in document order.
+func (did *DID) ContainerHierarchies() []*ContainerNode {
+	nodes := make([]*ContainerNode, len(did.Container))
+	nodesByID := make(map[string]*ContainerNode)
+	for i, container := range did.Container {
+		nodes[i] = &ContainerNode{Container: container}
+		id := string(container.ID)
+		if id != "" && nodesByID[id] == nil {
+			nodesByID[id] = nodes[i]
+		}
+	}
+
+	var roots []*ContainerNode
+	for _, node := range nodes {
+		parent := nodesByID[string(node.Container.Parent)]
+		if parent == nil || parent == node || isContainerAncestor(node, parent) {
+			roots = append(roots, node)
+			continue
+		}
+		parent.Children = append(parent.Children, node)
+	}
+
+	return roots
+}
+
+// isContainerAncestor returns true if node is an ancestor of other,
+// to prevent @parent cycles
+func isContainerAncestor(node *ContainerNode, other *ContainerNode) bool {
+	for _, child := range node.Children {
+		if child == other || isContainerAncestor(child, other) {
+			return true
+		}
+	}
+	return false
+}
+
+// PullListEntry is a row of a container pull list, used by reading room staff
+// to retrieve materials.
+//
+// Each entry is a path from a root container to a leaf container: Box is the
+// root (top) container, Folder the first level subcontainer, and Item the
+// second level subcontainer.  The Barcode and the cleaned up Label are taken
+// from the root container, e.g., label="Mixed Materials [31142042214224]".
+//
+// Path is the "Series I > Subseries 2" breadcrumb of the component's
+// ancestors' titles.
+type PullListEntry struct {
+	EADID       string `json:"eadid"`
+	ComponentID string `json:"componentid"`
+	Title       string `json:"title"`
+	Dates       string `json:"dates,omitempty"`
+	Path        string `json:"path,omitempty"`
+	BoxType     string `json:"boxtype"`
+	Box         string `json:"box"`
+	FolderType  string `json:"foldertype,omitempty"`
+	Folder      string `json:"folder,omitempty"`
+	ItemType    string `json:"itemtype,omitempty"`
+	Item        string `json:"item,omitempty"`
+	Barcode     string `json:"barcode,omitempty"`
+	Label       string `json:"label,omitempty"`
+}
+
+// PullListCSVHeader is the header row written by WritePullListCSV
+var PullListCSVHeader = []string{
+	"eadid", "componentid", "title", "dates", "path",
+	"boxtype", "box", "foldertype", "folder", "itemtype", "item",
+	"barcode", "label",
+}
+
+// PullListBreadcrumbSeparator separates the titles in PullListEntry.Path
+const PullListBreadcrumbSeparator = " > "
+
+// PullList returns a pull list entry for every container path of every
+// component, in document order.  Components without containers are omitted.
+func (e *EAD) PullList() []PullListEntry {
+	var entries []PullListEntry
+
+	e.WalkComponents(func(c *C, info *WalkInfo) error {
+		roots := c.DID.ContainerHierarchies()
+		if len(roots) == 0 {
+			return nil
+		}
+
+		entry := PullListEntry{
+			EADID:       e.EADID(),
+			ComponentID: string(c.ID),
+			Title:       getComponentPlainTextTitle(c),
+			Dates:       getUnitDatesPlainText(c.DID.UnitDate),
+		}
+
+		var path []string
+		for _, parent := range info.Parents {
+			path = append(path, getComponentPlainTextTitle(parent))
+		}
+		entry.Path = strings.Join(path, PullListBreadcrumbSeparator)
+
+		for _, root := range roots {
+			entries = appendPullListEntries(entries, entry, root)
+		}
+
+		return nil
+	})
+
+	return entries
+}
+
+func appendPullListEntries(entries []PullListEntry, entry PullListEntry, root *ContainerNode) []PullListEntry {
+	entry.BoxType = root.Type()
+	entry.Box = root.Indicator()
+	entry.Barcode = root.Container.Barcode
+	entry.Label = collapseWhitespace(removeBracketedText(string(root.Container.Label)))
+
+	if len(root.Children) == 0 {
+		return append(entries, entry)
+	}
+
+	for _, folder := range root.Children {
+		entry.FolderType = folder.Type()
+		entry.Folder = folder.Indicator()
+
+		if len(folder.Children) == 0 {
+			entries = append(entries, entry)
+			continue
+		}
+
+		// deeper subcontainers are not part of the pull list
+		for _, item := range folder.Children {
+			entry.ItemType = item.Type()
+			entry.Item = item.Indicator()
+			entries = append(entries, entry)
+		}
+		entry.ItemType = ""
+		entry.Item = ""
+	}
+
+	return entries
+}
+
+// barcodes are a single bracketed word, so other bracketed text is not
+// mistaken for a barcode
+var containerLabelBarcodeRegexp = regexp.MustCompile(`\[\s*([A-Za-z0-9][A-Za-z0-9._-]*)\s*\]`)
+
+// containerLabelBarcode returns the bracketed barcode of an ArchivesSpace
+// container @label, e.g., "31142042214224" for "Mixed Materials [31142042214224]"
+func containerLabelBarcode(label string) string {
+	m := containerLabelBarcodeRegexp.FindStringSubmatch(label)
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
+
+func getComponentPlainTextTitle(c *C) string {
+	if c.DID.UnitTitle == nil {
+		return ""
+	}
+	// the plain text is already unescaped
+	return collapseWhitespace(c.DID.UnitTitle.PlainText())
+}
+
+func getUnitDatesPlainText(unitDates []*UnitDate) string {
+	var dates []string
+	for _, unitDate := range unitDates {
+		date := collapseWhitespace(getInlinePlainText(unitDate.Value))
+		if date == "" {
+			date = string(unitDate.Normal)
+		}
+		if date != "" {
+			dates = append(dates, date)
+		}
+	}
+	return strings.Join(dates, "; ")
+}
+
+// WritePullListCSV writes the pull list entries as CSV with a header row
+func WritePullListCSV(w io.Writer, entries []PullListEntry) error {
+	csvWriter := csv.NewWriter(w)
+
+	err := csvWriter.Write(PullListCSVHeader)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		err = csvWriter.Write([]string{
+			entry.EADID, entry.ComponentID, entry.Title, entry.Dates, entry.Path,
+			entry.BoxType, entry.Box, entry.FolderType, entry.Folder, entry.ItemType, entry.Item,
+			entry.Barcode, entry.Label,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// WritePullListJSON writes the pull list entries as an indented JSON array.
+// HTML characters are not escaped, so the breadcrumb separator is written as-is.
+func WritePullListJSON(w io.Writer, entries []PullListEntry) error {
+	if entries == nil {
+		entries = []PullListEntry{}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "    ")
+	return encoder.Encode(entries)
+}
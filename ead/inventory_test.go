@@ -0,0 +1,190 @@
+package ead
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var inventoryTestFixturePath string = filepath.Join(testFixturePath, "inventory")
+
+type pullListTestParams struct {
+	TestName          string
+	EAD               *EAD
+	Write             func(w io.Writer, entries []PullListEntry) error
+	ReferenceFilePath string
+	ErrorFilePath     string
+}
+
+func runPullListComparisonTest(t *testing.T, params *pullListTestParams) {
+	t.Run(params.TestName, func(t *testing.T) {
+		var got bytes.Buffer
+		err := params.Write(&got, params.EAD.PullList())
+		failOnError(t, err, "Unexpected error writing pull list")
+
+		referenceFileContents, err := os.ReadFile(params.ReferenceFilePath)
+		failOnError(t, err, "Unexpected error reading reference file")
+
+		if !bytes.Equal(referenceFileContents, got.Bytes()) {
+			err = os.WriteFile(params.ErrorFilePath, got.Bytes(), 0644)
+			failOnError(t, err, fmt.Sprintf("Unexpected error writing %s", params.ErrorFilePath))
+
+			t.Errorf("Pull list does not match reference file.\ndiff %s %s", params.ErrorFilePath, params.ReferenceFilePath)
+		}
+	})
+}
+
+func formatContainerHierarchies(nodes []*ContainerNode) string {
+	var s []string
+	for _, node := range nodes {
+		if len(node.Children) == 0 {
+			s = append(s, node.Indicator())
+		} else {
+			s = append(s, node.Indicator()+"("+formatContainerHierarchies(node.Children)+")")
+		}
+	}
+	return strings.Join(s, " ")
+}
+
+func TestContainerHierarchies(t *testing.T) {
+	t.Run("Container Hierarchies", func(t *testing.T) {
+		did := DID{Container: []*Container{
+			{ID: "box1", Type: "Box", Value: "1"},
+			{ID: "folder1", Parent: "box1", Type: "Folder", Value: "F1"},
+			{ID: "item1", Parent: "folder1", Type: "Item", Value: "I1"},
+			{ID: "folder2", Parent: "box1", Type: "Folder", Value: "F2"},
+			{ID: "box2", Type: "Box", Value: " 2\n"},
+			{ID: "orphan", Parent: "missing", Type: "Folder", Value: "O"},
+		}}
+		assertEqual(t, "1(F1(I1) F2) 2 O", formatContainerHierarchies(did.ContainerHierarchies()), "Container hierarchies")
+
+		// @parent cycles are broken
+		did = DID{Container: []*Container{
+			{ID: "a", Parent: "b", Value: "A"},
+			{ID: "b", Parent: "a", Value: "B"},
+			{ID: "c", Parent: "c", Value: "C"},
+		}}
+		assertEqual(t, "B(A) C", formatContainerHierarchies(did.ContainerHierarchies()), "Container hierarchies with cycles")
+
+		did = DID{}
+		if len(did.ContainerHierarchies()) != 0 {
+			t.Errorf("Expected no container hierarchies")
+		}
+	})
+}
+
+func TestContainerLabelBarcode(t *testing.T) {
+	t.Run("Container Label Barcode", func(t *testing.T) {
+		assertEqual(t, "31142042214224", containerLabelBarcode("Mixed Materials [31142042214224]"), "Bracketed barcode")
+		assertEqual(t, "31142042214224", containerLabelBarcode("Mixed Materials [ 31142042214224 ]"), "Bracketed barcode with spaces")
+		assertEqual(t, "", containerLabelBarcode("mixed materials"), "No barcode")
+		assertEqual(t, "", containerLabelBarcode("Photographs [see also Box 2]"), "Bracketed text that is not a barcode")
+	})
+}
+
+func TestContainerBarcode(t *testing.T) {
+	t.Run("Container Barcode", func(t *testing.T) {
+		sut := getTestEAD(t, filepath.Join(testFixturePath, "fales", "mss_460.xml"))
+		container := sut.ArchDesc.DSC.C[0].C[0].DID.Container[0]
+
+		assertEqual(t, "31142063412350", container.Barcode, "Barcode parsed from @label")
+		assertEqual(t, "Mixed Materials [31142063412350]", string(container.Label), "Unmodified @label")
+
+		jsonData, err := json.Marshal(container)
+		failOnError(t, err, "Unexpected error marshaling JSON")
+		assertContains(t, string(jsonData), `"label":"Mixed Materials"`, "Display label without barcode")
+		assertContains(t, string(jsonData), `"barcode":"31142063412350"`, "Barcode")
+
+		// the barcode is not written as XML, and the @label is preserved
+		xmlData, roundTripped := marshalAndUnmarshalEAD(t, sut)
+		assertContains(t, xmlData, `label="Mixed Materials [31142063412350]"`, "Marshaled @label")
+		assertEqual(t, "31142063412350", roundTripped.ArchDesc.DSC.C[0].C[0].DID.Container[0].Barcode, "Round-tripped barcode")
+	})
+}
+
+func TestPullListEntries(t *testing.T) {
+	t.Run("Pull List Entries", func(t *testing.T) {
+		sut := EAD{ArchDesc: &ArchDesc{DSC: &DSC{C: []*C{
+			{ID: "series", DID: DID{UnitTitle: &UnitTitle{Value: "Series <emph>I</emph>"}}, C: []*C{
+				{ID: "file", DID: DID{
+					UnitTitle: &UnitTitle{Value: "Letters"},
+					UnitDate:  []*UnitDate{{Value: "1920-1925"}, {Value: " ", Normal: "1930/1931"}},
+					Container: []*Container{
+						{ID: "b", Type: "Box", Label: "Mixed Materials [123]", Value: "1", Barcode: "123"},
+						{ID: "f1", Parent: "b", Type: "Folder", Value: "1"},
+						{ID: "i1", Parent: "f1", Type: "Item", Value: "a"},
+						{ID: "i2", Parent: "f1", Type: "Item", Value: "b"},
+						{ID: "f2", Parent: "b", Type: "Folder", Value: "2"},
+					},
+				}},
+			}},
+		}}}}
+		sut.EADHeader.EADID.Value = "test"
+
+		var got []string
+		for _, entry := range sut.PullList() {
+			got = append(got, fmt.Sprintf("%s|%s|%s|%s|%s %s/%s %s/%s %s|%s|%s",
+				entry.ComponentID, entry.Title, entry.Dates, entry.Path,
+				entry.BoxType, entry.Box, entry.FolderType, entry.Folder, entry.ItemType, entry.Item,
+				entry.Barcode, entry.Label))
+		}
+
+		want := []string{
+			"file|Letters|1920-1925; 1930/1931|Series I|Box 1/Folder 1/Item a|123|Mixed Materials",
+			"file|Letters|1920-1925; 1930/1931|Series I|Box 1/Folder 1/Item b|123|Mixed Materials",
+			"file|Letters|1920-1925; 1930/1931|Series I|Box 1/Folder 2/ |123|Mixed Materials",
+		}
+		assertEqual(t, strings.Join(want, "\n"), strings.Join(got, "\n"), "Pull list entries")
+	})
+}
+
+func TestPullListEscapedEntities(t *testing.T) {
+	t.Run("Pull List Escaped Entities", func(t *testing.T) {
+		var c C
+		err := xml.Unmarshal([]byte(`<c id="file"><did><unittitle>Use &amp;lt;br&amp;gt; <emph>tags</emph></unittitle>`+
+			`<unitdate>1920 &amp;amp; 1930</unitdate><container id="b" type="Box" label="A &amp;amp; B">1</container></did></c>`), &c)
+		failOnError(t, err, "Unexpected error unmarshaling <c>")
+
+		sut := EAD{ArchDesc: &ArchDesc{DSC: &DSC{C: []*C{&c}}}}
+		entries := sut.PullList()
+		assertEqual(t, "1", fmt.Sprint(len(entries)), "Pull list entry count")
+		assertEqual(t, "Use &lt;br&gt; tags", entries[0].Title, "Title")
+		assertEqual(t, "1920 &amp; 1930", entries[0].Dates, "Dates")
+		assertEqual(t, "A &amp; B", entries[0].Label, "Label")
+	})
+}
+
+func TestPullListCSV(t *testing.T) {
+	sut := getOmegaEAD(t)
+
+	runPullListComparisonTest(t, &pullListTestParams{
+		TestName:          "Pull List CSV",
+		EAD:               &sut,
+		Write:             WritePullListCSV,
+		ReferenceFilePath: filepath.Join(inventoryTestFixturePath, "mos_2021-pull-list.csv"),
+		ErrorFilePath:     "./testdata/tmp/failing-mos_2021-pull-list.csv",
+	})
+}
+
+func TestPullListJSON(t *testing.T) {
+	runPullListComparisonTest(t, &pullListTestParams{
+		TestName:          "Pull List JSON",
+		EAD:               getTestEAD(t, filepath.Join(testFixturePath, "fales", "mss_460.xml")),
+		Write:             WritePullListJSON,
+		ReferenceFilePath: filepath.Join(inventoryTestFixturePath, "mss_460-pull-list.json"),
+		ErrorFilePath:     "./testdata/tmp/failing-mss_460-pull-list.json",
+	})
+
+	t.Run("Pull List JSON No Entries", func(t *testing.T) {
+		var got bytes.Buffer
+		err := WritePullListJSON(&got, nil)
+		failOnError(t, err, "Unexpected error writing pull list")
+		assertEqual(t, "[]\n", got.String(), "Empty pull list JSON")
+	})
+}
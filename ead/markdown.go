@@ -0,0 +1,614 @@
+package ead
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MarkdownHeadLevel is the heading level of the <head> of a note rendered
+// as Markdown.  The heads of nested notes are rendered one level lower.
+const MarkdownHeadLevel = 3
+
+// markdownBlock is a Markdown block, e.g., a paragraph or a list
+type markdownBlock struct {
+	Text   string
+	IsList bool
+}
+
+func joinMarkdownBlocks(blocks []markdownBlock) string {
+	var sb strings.Builder
+	for i, block := range blocks {
+		if i > 0 {
+			sb.WriteString("\n\n")
+		}
+		sb.WriteString(block.Text)
+	}
+	return sb.String()
+}
+
+// Markdown renders the inline content as Markdown, e.g., for Hugo content:
+//   - <emph> and <title> are rendered according to their @render, e.g.,
+//     "bold" as **text**, and "italic" as *text*.  <emph> and <title>
+//     without a @render are rendered as *text*.
+//   - <extref> and <ref> with an @href are rendered as [text](href) links,
+//     unless the href is not safe, see IsSafeURL()
+//   - <lb/> is rendered as a hard line break, and so is the end of an
+//     <addressline>, except in a <head>, where it is rendered as a space
+//   - <list> and <chronlist> are rendered as bullet or numbered lists
+//   - Markdown characters in the text are escaped
+func (ic InlineContent) Markdown() string {
+	return joinMarkdownBlocks(ic.markdownBlocks())
+}
+
+func (ic InlineContent) markdownBlocks() []markdownBlock {
+	w := &markdownWriter{}
+	w.writeContent(ic)
+	w.flush()
+	return w.blocks
+}
+
+// markdownWriter collects the blocks of a Markdown rendering.  "\n" in the
+// current paragraph marks a hard line break.
+type markdownWriter struct {
+	blocks    []markdownBlock
+	paragraph strings.Builder
+	// delimiters are the emphasis delimiters of the ancestors, which are
+	// not repeated for nested elements, e.g., bold <emph> in a bold <title>
+	delimiters []string
+	// singleLine renders line breaks as spaces, e.g., in headings
+	singleLine bool
+}
+
+var markdownOrderedListStartRegexp = regexp.MustCompile(`^(\d{1,9})([.)])(\s|$)`)
+var markdownBlockStartRegexp = regexp.MustCompile(`^([-+#])(\s|$)`)
+
+// flush ends the current paragraph.  The whitespace of each line is cleaned
+// up, and empty lines are dropped.
+func (w *markdownWriter) flush() {
+	var lines []string
+	for _, line := range strings.Split(w.paragraph.String(), "\n") {
+		line = strings.Join(strings.Fields(line), " ")
+		if line == "" {
+			continue
+		}
+		// prevent text like "1920. " or "- " from starting a list or heading
+		line = markdownOrderedListStartRegexp.ReplaceAllString(line, `$1\$2$3`)
+		line = markdownBlockStartRegexp.ReplaceAllString(line, `\$1$2`)
+		lines = append(lines, line)
+	}
+	w.paragraph.Reset()
+
+	if len(lines) > 0 {
+		w.blocks = append(w.blocks, markdownBlock{Text: strings.Join(lines, "\\\n")})
+	}
+}
+
+func (w *markdownWriter) writeContent(ic InlineContent) {
+	for _, node := range ic {
+		switch node.Type {
+		case InlineTextNode:
+			// collapse, but do not trim, the whitespace, e.g., "a <emph>b</emph> c"
+			w.paragraph.WriteString(escapeMarkdown(markdownWhitespaceRegexp.ReplaceAllString(node.Text, " ")))
+		case InlineElementNode:
+			w.writeElement(node)
+		}
+	}
+}
+
+func (w *markdownWriter) writeElement(node *InlineNode) {
+	switch node.LocalName() {
+	case "lb":
+		w.writeLineBreak()
+	case "head":
+		w.flush()
+		w.paragraph.WriteString(wrapMarkdownText(w.renderHeading(node.Children), "**", "**"))
+		w.flush()
+	case "p", "blockquote", "address":
+		w.flush()
+		w.writeContent(node.Children)
+		w.flush()
+	case "addressline":
+		w.writeContent(node.Children)
+		w.writeLineBreak()
+	case "list":
+		w.flush()
+		w.blocks = append(w.blocks, markdownInlineList(node)...)
+	case "chronlist":
+		w.flush()
+		w.blocks = append(w.blocks, markdownInlineChronList(node)...)
+	case "emph", "title":
+		w.writeEmphasis(node)
+	case "extref", "ref":
+		w.paragraph.WriteString(markdownLink(w.renderInline(node.Children), node.Attribute("href")))
+	case "extptr":
+		w.paragraph.WriteString(markdownLink("", node.Attribute("href")))
+	case "extent":
+		w.writeContent(node.Children)
+		if unit := node.Attribute("unit"); unit != "" {
+			w.paragraph.WriteString(" " + escapeMarkdown(unit))
+		}
+	default:
+		w.writeContent(node.Children)
+	}
+}
+
+func (w *markdownWriter) writeEmphasis(node *InlineNode) {
+	open, close := markdownRenderDelimiters(node.Attribute("render"))
+	for _, delimiter := range w.delimiters {
+		if delimiter == open {
+			open, close = "", ""
+			break
+		}
+	}
+
+	w.delimiters = append(w.delimiters, open)
+	text := w.renderInline(node.Children)
+	w.delimiters = w.delimiters[:len(w.delimiters)-1]
+
+	w.paragraph.WriteString(wrapMarkdownText(text, open, close))
+}
+
+// writeLineBreak writes a hard line break, or a space on a single line
+func (w *markdownWriter) writeLineBreak() {
+	if w.singleLine {
+		w.paragraph.WriteString(" ")
+	} else {
+		w.paragraph.WriteString("\n")
+	}
+}
+
+// renderHeading renders the inline content on a single line, because a
+// Markdown heading cannot contain a hard line break: <lb/> is rendered as a
+// space, and so are the breaks between blocks
+func (w *markdownWriter) renderHeading(ic InlineContent) string {
+	heading := &markdownWriter{delimiters: w.delimiters, singleLine: true}
+	heading.writeContent(ic)
+	heading.flush()
+
+	var texts []string
+	for _, block := range heading.blocks {
+		texts = append(texts, strings.ReplaceAll(block.Text, "\n", " "))
+	}
+	return strings.Join(texts, " ")
+}
+
+// renderInline renders the content as inline Markdown.  Block elements are
+// rendered as separate lines.
+func (w *markdownWriter) renderInline(ic InlineContent) string {
+	inline := &markdownWriter{delimiters: w.delimiters, singleLine: w.singleLine}
+	inline.writeContent(ic)
+
+	text := inline.paragraph.String()
+	for _, block := range inline.blocks {
+		text += "\n" + block.Text
+	}
+	return text
+}
+
+var markdownWhitespaceRegexp = regexp.MustCompile(`\s+`)
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "<", `\<`, ">", `\>`,
+)
+
+func escapeMarkdown(text string) string {
+	return markdownEscaper.Replace(text)
+}
+
+// markdownRenderDelimiters returns the Markdown delimiters for an EAD @render
+func markdownRenderDelimiters(render string) (string, string) {
+	switch render {
+	case "", "italic":
+		return "*", "*"
+	case "bold", "boldsmcaps", "boldunderline":
+		return "**", "**"
+	case "bolditalic":
+		return "***", "***"
+	case "doublequote":
+		return `"`, `"`
+	case "singlequote":
+		return "'", "'"
+	case "bolddoublequote":
+		return `**"`, `"**`
+	case "boldsinglequote":
+		return `**'`, `'**`
+	default:
+		return "", ""
+	}
+}
+
+// wrapMarkdownText wraps the text in the delimiters.  Leading and trailing
+// whitespace is moved outside of the delimiters so that Markdown emphasis
+// is recognized, e.g., "**bold** text" instead of "**bold **text".
+func wrapMarkdownText(text string, open string, close string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+
+	start := strings.Index(text, trimmed)
+	return text[:start] + open + trimmed + close + text[start+len(trimmed):]
+}
+
+func markdownLink(text string, href string) string {
+	if href == "" || !IsSafeURL(href) {
+		return text
+	}
+
+	if strings.TrimSpace(text) == "" {
+		text = escapeMarkdown(href)
+	}
+	if strings.ContainsAny(href, " ()<>") {
+		href = "<" + strings.NewReplacer("<", "%3C", ">", "%3E").Replace(href) + ">"
+	}
+	return wrapMarkdownText(text, "[", "]("+href+")")
+}
+
+// formatMarkdownList formats the items, each of which is a slice of blocks,
+// as a bullet or numbered list.  The lines following the first line of an
+// item are indented to the item's content.
+func formatMarkdownList(items [][]markdownBlock, ordered bool) markdownBlock {
+	var lines []string
+	for i, item := range items {
+		marker := "- "
+		if ordered {
+			marker = fmt.Sprintf("%d. ", i+1)
+		}
+		indent := strings.Repeat(" ", len(marker))
+
+		var sb strings.Builder
+		for j, block := range item {
+			if j > 0 {
+				// a nested list directly follows the text, so that the list stays tight
+				if block.IsList {
+					sb.WriteString("\n")
+				} else {
+					sb.WriteString("\n\n")
+				}
+			}
+			sb.WriteString(block.Text)
+		}
+
+		for j, line := range strings.Split(sb.String(), "\n") {
+			switch {
+			case j == 0:
+				lines = append(lines, marker+line)
+			case line == "":
+				lines = append(lines, "")
+			default:
+				lines = append(lines, indent+line)
+			}
+		}
+	}
+
+	return markdownBlock{Text: strings.Join(lines, "\n"), IsList: true}
+}
+
+func markdownHeadBlock(head string) []markdownBlock {
+	head = strings.TrimSpace(head)
+	if head == "" {
+		return nil
+	}
+	return []markdownBlock{{Text: "**" + head + "**"}}
+}
+
+func markdownInlineList(list *InlineNode) []markdownBlock {
+	var blocks []markdownBlock
+	var items [][]markdownBlock
+	for _, child := range list.Children {
+		switch child.LocalName() {
+		case "head":
+			blocks = append(blocks, markdownHeadBlock((&markdownWriter{}).renderHeading(child.Children))...)
+		case "item":
+			items = append(items, child.Children.markdownBlocks())
+		case "defitem":
+			var label, item string
+			for _, defItemChild := range child.Children {
+				switch defItemChild.LocalName() {
+				case "label":
+					label = defItemChild.Children.Markdown()
+				case "item":
+					item = defItemChild.Children.Markdown()
+				}
+			}
+			items = append(items, []markdownBlock{{Text: formatMarkdownDefItem(label, item)}})
+		}
+	}
+
+	if len(items) > 0 {
+		blocks = append(blocks, formatMarkdownList(items, list.Attribute("type") == "ordered"))
+	}
+	return blocks
+}
+
+func markdownInlineChronList(chronList *InlineNode) []markdownBlock {
+	var blocks []markdownBlock
+	var items [][]markdownBlock
+	for _, child := range chronList.Children {
+		switch child.LocalName() {
+		case "head":
+			blocks = append(blocks, markdownHeadBlock((&markdownWriter{}).renderHeading(child.Children))...)
+		case "chronitem":
+			items = append(items, []markdownBlock{{Text: markdownChronItem(child.Children)}})
+		}
+	}
+
+	if len(items) > 0 {
+		blocks = append(blocks, formatMarkdownList(items, false))
+	}
+	return blocks
+}
+
+func markdownChronItem(ic InlineContent) string {
+	var date string
+	var events []string
+	for _, child := range ic {
+		switch child.LocalName() {
+		case "date":
+			date = child.Children.Markdown()
+		case "event":
+			events = append(events, child.Children.Markdown())
+		case "eventgrp":
+			for _, event := range child.Children.Elements("event") {
+				events = append(events, event.Children.Markdown())
+			}
+		}
+	}
+
+	return formatMarkdownDefItem(date, strings.Join(events, "; "))
+}
+
+func formatMarkdownDefItem(label string, item string) string {
+	label = strings.TrimSpace(label)
+	item = strings.TrimSpace(item)
+	if label == "" {
+		return item
+	}
+	if item == "" {
+		return "**" + label + "**"
+	}
+	return "**" + label + "**: " + item
+}
+
+// inliner is a type with a generated Inline() method
+type inliner interface {
+	Inline() (InlineContent, error)
+}
+
+func markdownValue(value inliner) (string, error) {
+	content, err := value.Inline()
+	if err != nil {
+		return "", err
+	}
+	return content.Markdown(), nil
+}
+
+func markdownHeadingValue(value inliner) (string, error) {
+	content, err := value.Inline()
+	if err != nil {
+		return "", err
+	}
+	return (&markdownWriter{}).renderHeading(content), nil
+}
+
+func markdownValueBlocks(value inliner) ([]markdownBlock, error) {
+	content, err := value.Inline()
+	if err != nil {
+		return nil, err
+	}
+	return content.markdownBlocks(), nil
+}
+
+// Markdown renders the <p> as Markdown, see InlineContent.Markdown()
+func (p *P) Markdown() (string, error) {
+	return markdownValue(p)
+}
+
+// Markdown renders the <list> as a bullet list, or a numbered list if the
+// @type is "ordered", preceded by the <head> in bold
+func (list *List) Markdown() (string, error) {
+	blocks, err := list.markdownBlocks()
+	if err != nil {
+		return "", err
+	}
+	return joinMarkdownBlocks(blocks), nil
+}
+
+func (list *List) markdownBlocks() ([]markdownBlock, error) {
+	var blocks []markdownBlock
+	if list.Head != nil {
+		head, err := markdownHeadingValue(list.Head)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, markdownHeadBlock(head)...)
+	}
+
+	var items [][]markdownBlock
+	for _, item := range list.Item {
+		itemBlocks, err := markdownValueBlocks(item)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, itemBlocks)
+	}
+	for _, defItem := range list.DefItem {
+		text, err := defItem.Markdown()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, []markdownBlock{{Text: text}})
+	}
+
+	if len(items) > 0 {
+		blocks = append(blocks, formatMarkdownList(items, list.Type == "ordered"))
+	}
+	return blocks, nil
+}
+
+// Markdown renders the <chronlist> as a bullet list of "**date**: event; event"
+// items, preceded by the <head> in bold
+func (chronList *ChronList) Markdown() (string, error) {
+	blocks, err := chronList.markdownBlocks()
+	if err != nil {
+		return "", err
+	}
+	return joinMarkdownBlocks(blocks), nil
+}
+
+func (chronList *ChronList) markdownBlocks() ([]markdownBlock, error) {
+	var blocks []markdownBlock
+	if chronList.Head != nil {
+		head, err := markdownHeadingValue(chronList.Head)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, markdownHeadBlock(head)...)
+	}
+
+	var items [][]markdownBlock
+	for _, chronItem := range chronList.ChronItem {
+		content, err := chronItem.Inline()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, []markdownBlock{{Text: markdownChronItem(content)}})
+	}
+
+	if len(items) > 0 {
+		blocks = append(blocks, formatMarkdownList(items, false))
+	}
+	return blocks, nil
+}
+
+// Markdown renders the <defitem> as "**label**: item"
+func (defItem *DefItem) Markdown() (string, error) {
+	var items []string
+	for _, item := range defItem.Item {
+		text, err := markdownValue(item)
+		if err != nil {
+			return "", err
+		}
+		items = append(items, text)
+	}
+
+	label := escapeMarkdown(collapseWhitespace(string(defItem.Label)))
+	return formatMarkdownDefItem(label, strings.Join(items, " ")), nil
+}
+
+// Markdown renders the note as Markdown: the <head> as a heading of level
+// MarkdownHeadLevel, followed by the children in document order
+func (note *FormattedNoteWithHead) Markdown() (string, error) {
+	blocks, err := markdownNoteBlocks(note.Head, note.Children, MarkdownHeadLevel)
+	if err != nil {
+		return "", err
+	}
+	return joinMarkdownBlocks(blocks), nil
+}
+
+func markdownNoteBlocks(head *Head, children []*EADChild, level int) ([]markdownBlock, error) {
+	var blocks []markdownBlock
+	if head != nil {
+		text, err := markdownHeadingValue(head)
+		if err != nil {
+			return nil, err
+		}
+		if text != "" {
+			blocks = append(blocks, markdownBlock{Text: strings.Repeat("#", level) + " " + text})
+		}
+	}
+
+	for _, child := range children {
+		var childBlocks []markdownBlock
+		var err error
+
+		switch value := child.Value.(type) {
+		case *P:
+			childBlocks, err = markdownValueBlocks(value)
+		case *List:
+			childBlocks, err = value.markdownBlocks()
+		case *ChronList:
+			childBlocks, err = value.markdownBlocks()
+		case *DefItem:
+			var text string
+			text, err = value.Markdown()
+			childBlocks = []markdownBlock{{Text: text}}
+		case *BibRef:
+			childBlocks, err = markdownValueBlocks(value)
+		case *LegalStatus:
+			childBlocks, err = markdownValueBlocks(value)
+		case *FormattedNoteWithHead:
+			childBlocks, err = markdownNoteBlocks(value.Head, value.Children, level+1)
+		case *Bibliography:
+			childBlocks, err = markdownNoteBlocks(value.Head, value.Children, level+1)
+		case *BlockQuote:
+			childBlocks, err = markdownNoteBlocks(nil, value.Children, level)
+			if len(childBlocks) > 0 {
+				childBlocks = []markdownBlock{quoteMarkdownBlocks(childBlocks)}
+			}
+		case *Note:
+			childBlocks, err = value.markdownBlocks(level)
+		case *Address:
+			childBlocks, err = value.markdownBlocks()
+		case *Table:
+			childBlocks, err = value.markdownBlocks()
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		blocks = append(blocks, childBlocks...)
+	}
+
+	return blocks, nil
+}
+
+// quoteMarkdownBlocks renders the blocks as a single block quote
+func quoteMarkdownBlocks(blocks []markdownBlock) markdownBlock {
+	var lines []string
+	for _, line := range strings.Split(joinMarkdownBlocks(blocks), "\n") {
+		if line == "" {
+			lines = append(lines, ">")
+		} else {
+			lines = append(lines, "> "+line)
+		}
+	}
+	return markdownBlock{Text: strings.Join(lines, "\n")}
+}
+
+func (note *Note) markdownBlocks(level int) ([]markdownBlock, error) {
+	var blocks []markdownBlock
+	for _, p := range note.P {
+		pBlocks, err := markdownValueBlocks(p)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, pBlocks...)
+	}
+
+	childBlocks, err := markdownNoteBlocks(nil, note.Children, level)
+	if err != nil {
+		return nil, err
+	}
+	return append(blocks, childBlocks...), nil
+}
+
+// markdownBlocks renders each <addressline> on its own line
+func (address *Address) markdownBlocks() ([]markdownBlock, error) {
+	var lines []string
+	for _, addressLine := range address.AddressLine {
+		text, err := markdownValue(addressLine)
+		if err != nil {
+			return nil, err
+		}
+		if text != "" {
+			lines = append(lines, text)
+		}
+	}
+
+	if len(lines) == 0 {
+		return nil, nil
+	}
+	return []markdownBlock{{Text: strings.Join(lines, "\\\n")}}, nil
+}
@@ -0,0 +1,198 @@
+package ead
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var markdownTestFixturePath string = filepath.Join(testFixturePath, "markdown")
+
+func TestInlineContentMarkdown(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"Whitespace", "  Letters\n\t from   <persname>Jane</persname>  ", "Letters from Jane"},
+		{"Emph", `<emph render="bold">bold </emph>and <emph render="italic">italic</emph>, <emph>emph</emph>, <emph render="bolditalic">both</emph>`,
+			"**bold** and *italic*, *emph*, ***both***"},
+		{"Emph Quotes", `<emph render="doublequote">quoted</emph> <emph render="boldsinglequote">single</emph>`, `"quoted" **'single'**`},
+		{"Emph Unsupported Render", `<emph render="smcaps">small caps</emph>`, "small caps"},
+		{"Title", `<title render="italic">Moby Dick</title> and <title>Walden</title>`, "*Moby Dick* and *Walden*"},
+		{"Nested Emph", `<title render="bold"><emph render="bold">bold </emph>title</title>`, "**bold title**"},
+		{"Nested Different Emph", `<emph render="italic">a <emph render="bold">b</emph> c</emph>`, "*a **b** c*"},
+		{"Escaping", `2 * 3 = 6, [brackets], a_b, <emph>x</emph> &lt;tag&gt; \`, `2 \* 3 = 6, \[brackets\], a\_b, *x* \<tag\> \\`},
+		{"Line Start", "1920. A year", `1920\. A year`},
+		{"Line Breaks", "123 Main St.<lb/>Springfield,<lb/><lb/>\n  USA", "123 Main St.\\\nSpringfield,\\\nUSA"},
+		{"ExtRef", `See <extref xlink:href="https://example.org">the website</extref>.`, "See [the website](https://example.org)."},
+		{"ExtRef No Text", `See <extref href="https://example.org"/>`, `See [https://example.org](https://example.org)`},
+		{"ExtRef Parentheses", `<extref href="https://example.org/a (b)">link</extref>`, "[link](<https://example.org/a (b)>)"},
+		{"ExtRef No URL", `See <extref>the website</extref>`, "See the website"},
+		{"ExtRef Unsafe URL", `See <extref href="javascript:alert(1)">the website</extref>`, "See the website"},
+		{"Extent", `<extent unit="boxes">2</extent>`, "2 boxes"},
+		{"List", `Contents:<list><head>Formats</head><item>Letters</item><item>Photographs,<lb/>mostly color</item></list>End`,
+			"Contents:\n\n**Formats**\n\n- Letters\n- Photographs,\\\n  mostly color\n\nEnd"},
+		{"Nested List", `<list><item>Series I<list type="ordered"><item>Subseries 1</item><item>Subseries 2</item></list></item><item>Series II</item></list>`,
+			"- Series I\n  1. Subseries 1\n  2. Subseries 2\n- Series II"},
+		{"Definition List", `<list type="deflist"><listhead><head01>Term</head01></listhead><defitem><label>ALS</label><item>Autograph letter signed</item></defitem></list>`,
+			"- **ALS**: Autograph letter signed"},
+		{"ChronList", `<chronlist><chronitem><date>1920</date><event>Born</event></chronitem><chronitem><date>1945</date><eventgrp><event>Married</event><event>Moved</event></eventgrp></chronitem></chronlist>`,
+			"- **1920**: Born\n- **1945**: Married; Moved"},
+	}
+
+	for _, tc := range testCases {
+		t.Run("InlineContent Markdown "+tc.name, func(t *testing.T) {
+			content, err := ParseInlineContent(tc.value)
+			failOnError(t, err, "Unexpected error parsing inline content")
+			assertEqual(t, tc.want, content.Markdown(), "Markdown()")
+		})
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	t.Run("P Markdown", func(t *testing.T) {
+		p := P{Value: `See <title render="italic">Series I</title>.`}
+		got, err := p.Markdown()
+		failOnError(t, err, "Unexpected error rendering Markdown")
+		assertEqual(t, "See *Series I*.", got, "P.Markdown()")
+
+		p = P{Value: "<emph>unclosed"}
+		_, err = p.Markdown()
+		if err == nil {
+			t.Errorf("Expected an error for a <p> that is not well-formed")
+		}
+	})
+
+	t.Run("List Markdown", func(t *testing.T) {
+		list := List{
+			Type: "ordered",
+			Head: &Head{Value: "Series"},
+			Item: []*Item{{Value: "Letters"}, {Value: `<emph render="bold">Photographs</emph>`}},
+		}
+		got, err := list.Markdown()
+		failOnError(t, err, "Unexpected error rendering Markdown")
+		assertEqual(t, "**Series**\n\n1. Letters\n2. **Photographs**", got, "List.Markdown()")
+	})
+
+	t.Run("ChronList Markdown", func(t *testing.T) {
+		chronList := ChronList{ChronItem: []*ChronItem{
+			{Value: "<date>1920</date><event>Born in <geogname>Paris</geogname></event>"},
+			{Value: "<date>1945</date>"},
+		}}
+		got, err := chronList.Markdown()
+		failOnError(t, err, "Unexpected error rendering Markdown")
+		assertEqual(t, "- **1920**: Born in Paris\n- **1945**", got, "ChronList.Markdown()")
+	})
+
+	t.Run("DefItem Markdown", func(t *testing.T) {
+		defItem := DefItem{Label: "TLS [typed]", Item: []*Item{{Value: "Typed letter signed"}}}
+		got, err := defItem.Markdown()
+		failOnError(t, err, "Unexpected error rendering Markdown")
+		assertEqual(t, `**TLS \[typed\]**: Typed letter signed`, got, "DefItem.Markdown()")
+	})
+
+	t.Run("FormattedNoteWithHead Markdown", func(t *testing.T) {
+		note := FormattedNoteWithHead{
+			Head: &Head{Value: "Biographical Note"},
+			Children: []*EADChild{
+				{Name: "p", Value: &P{Value: "First."}},
+				{Name: "bioghist", Value: &FormattedNoteWithHead{
+					Head:     &Head{Value: "Early Life"},
+					Children: []*EADChild{{Name: "p", Value: &P{Value: "Second."}}},
+				}},
+			},
+		}
+		got, err := note.Markdown()
+		failOnError(t, err, "Unexpected error rendering Markdown")
+		assertEqual(t, "### Biographical Note\n\nFirst.\n\n#### Early Life\n\nSecond.", got, "FormattedNoteWithHead.Markdown()")
+	})
+
+	t.Run("Head With Line Break Markdown", func(t *testing.T) {
+		note := FormattedNoteWithHead{
+			Head:     &Head{Value: "Line<lb/>two"},
+			Children: []*EADChild{{Name: "p", Value: &P{Value: "First."}}},
+		}
+		got, err := note.Markdown()
+		failOnError(t, err, "Unexpected error rendering Markdown")
+		assertEqual(t, "### Line two\n\nFirst.", got, "FormattedNoteWithHead.Markdown()")
+
+		list := List{Head: &Head{Value: "Line<lb/>two"}, Item: []*Item{{Value: "Letters"}}}
+		got, err = list.Markdown()
+		failOnError(t, err, "Unexpected error rendering Markdown")
+		assertEqual(t, "**Line two**\n\n- Letters", got, "List.Markdown()")
+
+		p := P{Value: "<list><head>Line<lb/>two</head><item>Letters</item></list>"}
+		got, err = p.Markdown()
+		failOnError(t, err, "Unexpected error rendering Markdown")
+		assertEqual(t, "**Line two**\n\n- Letters", got, "P.Markdown()")
+	})
+}
+
+func TestBlockElementsMarkdown(t *testing.T) {
+	t.Run("Block Elements Markdown", func(t *testing.T) {
+		sut := getTestEAD(t, filepath.Join(blockElementsTestFixturePath, "block-elements.xml"))
+		got, err := sut.ArchDesc.ScopeContent[0].Markdown()
+		failOnError(t, err, "Unexpected error rendering Markdown")
+
+		assertContains(t, got, "> We have *always* kept the letters.\n>\n> - Letters\n> - Photographs", "<blockquote>")
+		assertContains(t, got, "Some letters are fragile.\n\n1. Handle with gloves", "<note>")
+		assertContains(t, got, "Tamiment Library\\\n70 Washington Square South", "<address>")
+		assertContains(t, got, "**Series Overview**\n\n| Series | Extent |  |\n| --- | --- | --- |\n"+
+			"| Series I: **Correspondence** | 2 | boxes |\n|  | 1 | folder |", "<table>")
+	})
+}
+
+// appendNotesMarkdown appends the Markdown of the []*FormattedNoteWithHead
+// fields of the struct, e.g., ArchDesc.ScopeContent, in field order
+func appendNotesMarkdown(t *testing.T, sb *strings.Builder, location string, v reflect.Value) {
+	noteSliceType := reflect.TypeOf([]*FormattedNoteWithHead{})
+	for i := 0; i < v.NumField(); i++ {
+		if v.Field(i).Type() != noteSliceType {
+			continue
+		}
+
+		name := strings.Split(v.Type().Field(i).Tag.Get("xml"), ",")[0]
+		for _, note := range v.Field(i).Interface().([]*FormattedNoteWithHead) {
+			markdown, err := note.Markdown()
+			failOnError(t, err, "Unexpected error rendering Markdown")
+			fmt.Fprintf(sb, "<!-- %s/%s -->\n%s\n\n", location, name, markdown)
+		}
+	}
+}
+
+func runMarkdownComparisonTest(t *testing.T, eadPath string, referenceFileName string) {
+	t.Run(fmt.Sprintf("Markdown %s", filepath.Base(eadPath)), func(t *testing.T) {
+		sut := getTestEAD(t, eadPath)
+
+		var sb strings.Builder
+		appendNotesMarkdown(t, &sb, "archdesc", reflect.ValueOf(*sut.ArchDesc))
+		sut.WalkComponents(func(c *C, info *WalkInfo) error {
+			appendNotesMarkdown(t, &sb, "c "+string(c.ID), reflect.ValueOf(*c))
+			return nil
+		})
+		got := []byte(sb.String())
+
+		referenceFilePath := filepath.Join(markdownTestFixturePath, referenceFileName)
+		referenceFileContents, err := os.ReadFile(referenceFilePath)
+		failOnError(t, err, "Unexpected error reading reference file")
+
+		if !bytes.Equal(referenceFileContents, got) {
+			errorFilePath := "./testdata/tmp/failing-" + referenceFileName
+			err = os.WriteFile(errorFilePath, got, 0644)
+			failOnError(t, err, fmt.Sprintf("Unexpected error writing %s", errorFilePath))
+
+			t.Errorf("Markdown does not match reference file.\ndiff %s %s", errorFilePath, referenceFilePath)
+		}
+	})
+}
+
+func TestMarkdownFixtures(t *testing.T) {
+	runMarkdownComparisonTest(t, filepath.Join(omegaTestFixturePath, "Omega-EAD.xml"), "mos_2021.md")
+	runMarkdownComparisonTest(t, filepath.Join(testFixturePath, "nyhs", "ms256_harmon_hendricks_goldstone.xml"), "ms256_harmon_hendricks_goldstone.md")
+	runMarkdownComparisonTest(t, filepath.Join(testFixturePath, "cbh", "arc_212_plymouth_beecher.xml"), "arc_212_plymouth_beecher.md")
+}
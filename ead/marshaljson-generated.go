@@ -4,13 +4,14 @@ package ead
 
 import (
 	"encoding/json"
+	"encoding/xml"
 	"regexp"
 )
 
 func (abstract *Abstract) MarshalJSON() ([]byte, error) {
 	type AbstractWithTags Abstract
 
-	result, err := getConvertedTextWithTags(abstract.Value)
+	content, err := abstract.Inline()
 	if err != nil {
 		return nil, err
 	}
@@ -19,7 +20,7 @@ func (abstract *Abstract) MarshalJSON() ([]byte, error) {
 		Value string `json:"value,omitempty"`
 		*AbstractWithTags
 	}{
-		Value:            string(result),
+		Value:            abstract.htmlRenderer().HTML(content),
 		AbstractWithTags: (*AbstractWithTags)(abstract),
 	})
 	if err != nil {
@@ -32,7 +33,7 @@ func (abstract *Abstract) MarshalJSON() ([]byte, error) {
 func (addressline *AddressLine) MarshalJSON() ([]byte, error) {
 	type AddressLineWithTags AddressLine
 
-	result, err := getConvertedTextWithTags(addressline.Value)
+	content, err := addressline.Inline()
 	if err != nil {
 		return nil, err
 	}
@@ -41,7 +42,7 @@ func (addressline *AddressLine) MarshalJSON() ([]byte, error) {
 		Value string `json:"value,omitempty"`
 		*AddressLineWithTags
 	}{
-		Value:               string(result),
+		Value:               addressline.htmlRenderer().HTML(content),
 		AddressLineWithTags: (*AddressLineWithTags)(addressline),
 	})
 	if err != nil {
@@ -54,7 +55,7 @@ func (addressline *AddressLine) MarshalJSON() ([]byte, error) {
 func (archref *ArchRef) MarshalJSON() ([]byte, error) {
 	type ArchRefWithTags ArchRef
 
-	result, err := getConvertedTextWithTags(archref.Value)
+	content, err := archref.Inline()
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +64,7 @@ func (archref *ArchRef) MarshalJSON() ([]byte, error) {
 		Value string `json:"value,omitempty"`
 		*ArchRefWithTags
 	}{
-		Value:           string(result),
+		Value:           archref.htmlRenderer().HTML(content),
 		ArchRefWithTags: (*ArchRefWithTags)(archref),
 	})
 	if err != nil {
@@ -76,7 +77,7 @@ func (archref *ArchRef) MarshalJSON() ([]byte, error) {
 func (bibref *BibRef) MarshalJSON() ([]byte, error) {
 	type BibRefWithTags BibRef
 
-	result, err := getConvertedTextWithTags(bibref.Value)
+	content, err := bibref.Inline()
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +86,7 @@ func (bibref *BibRef) MarshalJSON() ([]byte, error) {
 		Value string `json:"value,omitempty"`
 		*BibRefWithTags
 	}{
-		Value:          string(result),
+		Value:          bibref.htmlRenderer().HTML(content),
 		BibRefWithTags: (*BibRefWithTags)(bibref),
 	})
 	if err != nil {
@@ -98,7 +99,7 @@ func (bibref *BibRef) MarshalJSON() ([]byte, error) {
 func (chronitem *ChronItem) MarshalJSON() ([]byte, error) {
 	type ChronItemWithTags ChronItem
 
-	result, err := getConvertedTextWithTags(chronitem.Value)
+	content, err := chronitem.Inline()
 	if err != nil {
 		return nil, err
 	}
@@ -107,7 +108,7 @@ func (chronitem *ChronItem) MarshalJSON() ([]byte, error) {
 		Value string `json:"value,omitempty"`
 		*ChronItemWithTags
 	}{
-		Value:             string(result),
+		Value:             chronitem.htmlRenderer().HTML(content),
 		ChronItemWithTags: (*ChronItemWithTags)(chronitem),
 	})
 	if err != nil {
@@ -120,7 +121,7 @@ func (chronitem *ChronItem) MarshalJSON() ([]byte, error) {
 func (container *Container) MarshalJSON() ([]byte, error) {
 	type ContainerWithTags Container
 
-	result, err := getConvertedTextWithTags(container.Value)
+	content, err := container.Inline()
 	if err != nil {
 		return nil, err
 	}
@@ -129,7 +130,7 @@ func (container *Container) MarshalJSON() ([]byte, error) {
 		Value string `json:"value,omitempty"`
 		*ContainerWithTags
 	}{
-		Value:             string(result),
+		Value:             container.htmlRenderer().HTML(content),
 		ContainerWithTags: (*ContainerWithTags)(container),
 	})
 	if err != nil {
@@ -142,7 +143,7 @@ func (container *Container) MarshalJSON() ([]byte, error) {
 func (creation *Creation) MarshalJSON() ([]byte, error) {
 	type CreationWithTags Creation
 
-	result, err := getConvertedTextWithTags(creation.Value)
+	content, err := creation.Inline()
 	if err != nil {
 		return nil, err
 	}
@@ -151,7 +152,7 @@ func (creation *Creation) MarshalJSON() ([]byte, error) {
 		Value string `json:"value,omitempty"`
 		*CreationWithTags
 	}{
-		Value:            string(result),
+		Value:            creation.htmlRenderer().HTML(content),
 		CreationWithTags: (*CreationWithTags)(creation),
 	})
 	if err != nil {
@@ -164,7 +165,7 @@ func (creation *Creation) MarshalJSON() ([]byte, error) {
 func (date *Date) MarshalJSON() ([]byte, error) {
 	type DateWithTags Date
 
-	result, err := getConvertedTextWithTags(date.Value)
+	content, err := date.Inline()
 	if err != nil {
 		return nil, err
 	}
@@ -173,7 +174,7 @@ func (date *Date) MarshalJSON() ([]byte, error) {
 		Value string `json:"value,omitempty"`
 		*DateWithTags
 	}{
-		Value:        string(result),
+		Value:        date.htmlRenderer().HTML(content),
 		DateWithTags: (*DateWithTags)(date),
 	})
 	if err != nil {
@@ -186,7 +187,7 @@ func (date *Date) MarshalJSON() ([]byte, error) {
 func (dimensions *Dimensions) MarshalJSON() ([]byte, error) {
 	type DimensionsWithTags Dimensions
 
-	result, err := getConvertedTextWithTags(dimensions.Value)
+	content, err := dimensions.Inline()
 	if err != nil {
 		return nil, err
 	}
@@ -195,7 +196,7 @@ func (dimensions *Dimensions) MarshalJSON() ([]byte, error) {
 		Value string `json:"value,omitempty"`
 		*DimensionsWithTags
 	}{
-		Value:              string(result),
+		Value:              dimensions.htmlRenderer().HTML(content),
 		DimensionsWithTags: (*DimensionsWithTags)(dimensions),
 	})
 	if err != nil {
@@ -205,10 +206,32 @@ func (dimensions *Dimensions) MarshalJSON() ([]byte, error) {
 	return jsonData, nil
 }
 
+func (entry *Entry) MarshalJSON() ([]byte, error) {
+	type EntryWithTags Entry
+
+	content, err := entry.Inline()
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(&struct {
+		Value string `json:"value,omitempty"`
+		*EntryWithTags
+	}{
+		Value:         entry.htmlRenderer().HTML(content),
+		EntryWithTags: (*EntryWithTags)(entry),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonData, nil
+}
+
 func (event *Event) MarshalJSON() ([]byte, error) {
 	type EventWithTags Event
 
-	result, err := getConvertedTextWithTags(event.Value)
+	content, err := event.Inline()
 	if err != nil {
 		return nil, err
 	}
@@ -217,7 +240,7 @@ func (event *Event) MarshalJSON() ([]byte, error) {
 		Value string `json:"value,omitempty"`
 		*EventWithTags
 	}{
-		Value:         string(result),
+		Value:         event.htmlRenderer().HTML(content),
 		EventWithTags: (*EventWithTags)(event),
 	})
 	if err != nil {
@@ -230,7 +253,7 @@ func (event *Event) MarshalJSON() ([]byte, error) {
 func (head *Head) MarshalJSON() ([]byte, error) {
 	type HeadWithTags Head
 
-	result, err := getConvertedTextWithTags(head.Value)
+	content, err := head.Inline()
 	if err != nil {
 		return nil, err
 	}
@@ -239,7 +262,7 @@ func (head *Head) MarshalJSON() ([]byte, error) {
 		Value string `json:"value,omitempty"`
 		*HeadWithTags
 	}{
-		Value:        string(result),
+		Value:        head.htmlRenderer().HTML(content),
 		HeadWithTags: (*HeadWithTags)(head),
 	})
 	if err != nil {
@@ -252,7 +275,7 @@ func (head *Head) MarshalJSON() ([]byte, error) {
 func (item *Item) MarshalJSON() ([]byte, error) {
 	type ItemWithTags Item
 
-	result, err := getConvertedTextWithTags(item.Value)
+	content, err := item.Inline()
 	if err != nil {
 		return nil, err
 	}
@@ -261,7 +284,7 @@ func (item *Item) MarshalJSON() ([]byte, error) {
 		Value string `json:"value,omitempty"`
 		*ItemWithTags
 	}{
-		Value:        string(result),
+		Value:        item.htmlRenderer().HTML(content),
 		ItemWithTags: (*ItemWithTags)(item),
 	})
 	if err != nil {
@@ -274,7 +297,7 @@ func (item *Item) MarshalJSON() ([]byte, error) {
 func (langmaterial *LangMaterial) MarshalJSON() ([]byte, error) {
 	type LangMaterialWithTags LangMaterial
 
-	result, err := getConvertedTextWithTags(langmaterial.Value)
+	content, err := langmaterial.Inline()
 	if err != nil {
 		return nil, err
 	}
@@ -283,7 +306,7 @@ func (langmaterial *LangMaterial) MarshalJSON() ([]byte, error) {
 		Value string `json:"value,omitempty"`
 		*LangMaterialWithTags
 	}{
-		Value:                string(result),
+		Value:                langmaterial.htmlRenderer().HTML(content),
 		LangMaterialWithTags: (*LangMaterialWithTags)(langmaterial),
 	})
 	if err != nil {
@@ -296,7 +319,7 @@ func (langmaterial *LangMaterial) MarshalJSON() ([]byte, error) {
 func (langusage *LangUsage) MarshalJSON() ([]byte, error) {
 	type LangUsageWithTags LangUsage
 
-	result, err := getConvertedTextWithTags(langusage.Value)
+	content, err := langusage.Inline()
 	if err != nil {
 		return nil, err
 	}
@@ -305,7 +328,7 @@ func (langusage *LangUsage) MarshalJSON() ([]byte, error) {
 		Value string `json:"value,omitempty"`
 		*LangUsageWithTags
 	}{
-		Value:             string(result),
+		Value:             langusage.htmlRenderer().HTML(content),
 		LangUsageWithTags: (*LangUsageWithTags)(langusage),
 	})
 	if err != nil {
@@ -318,7 +341,7 @@ func (langusage *LangUsage) MarshalJSON() ([]byte, error) {
 func (legalstatus *LegalStatus) MarshalJSON() ([]byte, error) {
 	type LegalStatusWithTags LegalStatus
 
-	result, err := getConvertedTextWithTags(legalstatus.Value)
+	content, err := legalstatus.Inline()
 	if err != nil {
 		return nil, err
 	}
@@ -327,7 +350,7 @@ func (legalstatus *LegalStatus) MarshalJSON() ([]byte, error) {
 		Value string `json:"value,omitempty"`
 		*LegalStatusWithTags
 	}{
-		Value:               string(result),
+		Value:               legalstatus.htmlRenderer().HTML(content),
 		LegalStatusWithTags: (*LegalStatusWithTags)(legalstatus),
 	})
 	if err != nil {
@@ -340,7 +363,7 @@ func (legalstatus *LegalStatus) MarshalJSON() ([]byte, error) {
 func (num *Num) MarshalJSON() ([]byte, error) {
 	type NumWithTags Num
 
-	result, err := getConvertedTextWithTags(num.Value)
+	content, err := num.Inline()
 	if err != nil {
 		return nil, err
 	}
@@ -349,7 +372,7 @@ func (num *Num) MarshalJSON() ([]byte, error) {
 		Value string `json:"value,omitempty"`
 		*NumWithTags
 	}{
-		Value:       string(result),
+		Value:       num.htmlRenderer().HTML(content),
 		NumWithTags: (*NumWithTags)(num),
 	})
 	if err != nil {
@@ -362,7 +385,7 @@ func (num *Num) MarshalJSON() ([]byte, error) {
 func (p *P) MarshalJSON() ([]byte, error) {
 	type PWithTags P
 
-	result, err := getConvertedTextWithTags(p.Value)
+	content, err := p.Inline()
 	if err != nil {
 		return nil, err
 	}
@@ -371,7 +394,7 @@ func (p *P) MarshalJSON() ([]byte, error) {
 		Value string `json:"value,omitempty"`
 		*PWithTags
 	}{
-		Value:     string(result),
+		Value:     p.htmlRenderer().HTML(content),
 		PWithTags: (*PWithTags)(p),
 	})
 	if err != nil {
@@ -384,7 +407,7 @@ func (p *P) MarshalJSON() ([]byte, error) {
 func (physfacet *PhysFacet) MarshalJSON() ([]byte, error) {
 	type PhysFacetWithTags PhysFacet
 
-	result, err := getConvertedTextWithTags(physfacet.Value)
+	content, err := physfacet.Inline()
 	if err != nil {
 		return nil, err
 	}
@@ -393,7 +416,7 @@ func (physfacet *PhysFacet) MarshalJSON() ([]byte, error) {
 		Value string `json:"value,omitempty"`
 		*PhysFacetWithTags
 	}{
-		Value:             string(result),
+		Value:             physfacet.htmlRenderer().HTML(content),
 		PhysFacetWithTags: (*PhysFacetWithTags)(physfacet),
 	})
 	if err != nil {
@@ -406,7 +429,7 @@ func (physfacet *PhysFacet) MarshalJSON() ([]byte, error) {
 func (physloc *PhysLoc) MarshalJSON() ([]byte, error) {
 	type PhysLocWithTags PhysLoc
 
-	result, err := getConvertedTextWithTags(physloc.Value)
+	content, err := physloc.Inline()
 	if err != nil {
 		return nil, err
 	}
@@ -415,7 +438,7 @@ func (physloc *PhysLoc) MarshalJSON() ([]byte, error) {
 		Value string `json:"value,omitempty"`
 		*PhysLocWithTags
 	}{
-		Value:           string(result),
+		Value:           physloc.htmlRenderer().HTML(content),
 		PhysLocWithTags: (*PhysLocWithTags)(physloc),
 	})
 	if err != nil {
@@ -428,7 +451,7 @@ func (physloc *PhysLoc) MarshalJSON() ([]byte, error) {
 func (repository *Repository) MarshalJSON() ([]byte, error) {
 	type RepositoryWithTags Repository
 
-	result, err := getConvertedTextWithTags(repository.Value)
+	content, err := repository.Inline()
 	if err != nil {
 		return nil, err
 	}
@@ -437,7 +460,7 @@ func (repository *Repository) MarshalJSON() ([]byte, error) {
 		Value string `json:"value,omitempty"`
 		*RepositoryWithTags
 	}{
-		Value:              string(result),
+		Value:              repository.htmlRenderer().HTML(content),
 		RepositoryWithTags: (*RepositoryWithTags)(repository),
 	})
 	if err != nil {
@@ -450,7 +473,7 @@ func (repository *Repository) MarshalJSON() ([]byte, error) {
 func (title *Title) MarshalJSON() ([]byte, error) {
 	type TitleWithTags Title
 
-	result, err := getConvertedTextWithTagsNoLBConversion(title.Value)
+	content, err := title.Inline()
 	if err != nil {
 		return nil, err
 	}
@@ -459,7 +482,7 @@ func (title *Title) MarshalJSON() ([]byte, error) {
 		Value string `json:"value,omitempty"`
 		*TitleWithTags
 	}{
-		Value:         string(result),
+		Value:         title.htmlRenderer().HTMLNoLBConversion(content),
 		TitleWithTags: (*TitleWithTags)(title),
 	})
 	if err != nil {
@@ -469,32 +492,10 @@ func (title *Title) MarshalJSON() ([]byte, error) {
 	return jsonData, nil
 }
 
-func (unitdate *UnitDate) MarshalJSON() ([]byte, error) {
-	type UnitDateWithTags UnitDate
-
-	result, err := getConvertedTextWithTags(unitdate.Value)
-	if err != nil {
-		return nil, err
-	}
-
-	jsonData, err := json.Marshal(&struct {
-		Value string `json:"value,omitempty"`
-		*UnitDateWithTags
-	}{
-		Value:            string(result),
-		UnitDateWithTags: (*UnitDateWithTags)(unitdate),
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	return jsonData, nil
-}
-
 func (unittitle *UnitTitle) MarshalJSON() ([]byte, error) {
 	type UnitTitleWithTags UnitTitle
 
-	result, err := getConvertedTextWithTags(unittitle.Value)
+	content, err := unittitle.Inline()
 	if err != nil {
 		return nil, err
 	}
@@ -503,7 +504,7 @@ func (unittitle *UnitTitle) MarshalJSON() ([]byte, error) {
 		Value string `json:"value,omitempty"`
 		*UnitTitleWithTags
 	}{
-		Value:             string(result),
+		Value:             unittitle.htmlRenderer().HTML(content),
 		UnitTitleWithTags: (*UnitTitleWithTags)(unittitle),
 	})
 	if err != nil {
@@ -523,12 +524,12 @@ func (physdesc *PhysDesc) MarshalJSON() ([]byte, error) {
 
 	var value string
 	if containsNonWhitespace {
-		result, err := getConvertedTextWithTags(physdesc.Value)
+		content, err := physdesc.Inline()
 		if err != nil {
 			return nil, err
 		}
 
-		value = string(result)
+		value = physdesc.htmlRenderer().HTML(content)
 	} else {
 		value = ""
 	}
@@ -546,3 +547,665 @@ func (physdesc *PhysDesc) MarshalJSON() ([]byte, error) {
 
 	return jsonData, nil
 }
+
+// UnmarshalXML decodes the Abstract, and parses its Value for Inline()
+func (abstract *Abstract) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type AbstractAlias Abstract
+	if err := d.DecodeElement((*AbstractAlias)(abstract), &start); err != nil {
+		return err
+	}
+	abstract.inlineValue.parse(abstract.Value)
+	return nil
+}
+
+// UnmarshalXML decodes the AddressLine, and parses its Value for Inline()
+func (addressline *AddressLine) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type AddressLineAlias AddressLine
+	if err := d.DecodeElement((*AddressLineAlias)(addressline), &start); err != nil {
+		return err
+	}
+	addressline.inlineValue.parse(addressline.Value)
+	return nil
+}
+
+// UnmarshalXML decodes the ArchRef, and parses its Value for Inline()
+func (archref *ArchRef) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type ArchRefAlias ArchRef
+	if err := d.DecodeElement((*ArchRefAlias)(archref), &start); err != nil {
+		return err
+	}
+	archref.inlineValue.parse(archref.Value)
+	return nil
+}
+
+// UnmarshalXML decodes the BibRef, and parses its Value for Inline()
+func (bibref *BibRef) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type BibRefAlias BibRef
+	if err := d.DecodeElement((*BibRefAlias)(bibref), &start); err != nil {
+		return err
+	}
+	bibref.inlineValue.parse(bibref.Value)
+	return nil
+}
+
+// UnmarshalXML decodes the ChronItem, and parses its Value for Inline()
+func (chronitem *ChronItem) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type ChronItemAlias ChronItem
+	if err := d.DecodeElement((*ChronItemAlias)(chronitem), &start); err != nil {
+		return err
+	}
+	chronitem.inlineValue.parse(chronitem.Value)
+	return nil
+}
+
+// UnmarshalXML decodes the Creation, and parses its Value for Inline()
+func (creation *Creation) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type CreationAlias Creation
+	if err := d.DecodeElement((*CreationAlias)(creation), &start); err != nil {
+		return err
+	}
+	creation.inlineValue.parse(creation.Value)
+	return nil
+}
+
+// UnmarshalXML decodes the Date, and parses its Value for Inline()
+func (date *Date) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type DateAlias Date
+	if err := d.DecodeElement((*DateAlias)(date), &start); err != nil {
+		return err
+	}
+	date.inlineValue.parse(date.Value)
+	return nil
+}
+
+// UnmarshalXML decodes the Dimensions, and parses its Value for Inline()
+func (dimensions *Dimensions) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type DimensionsAlias Dimensions
+	if err := d.DecodeElement((*DimensionsAlias)(dimensions), &start); err != nil {
+		return err
+	}
+	dimensions.inlineValue.parse(dimensions.Value)
+	return nil
+}
+
+// UnmarshalXML decodes the Entry, and parses its Value for Inline()
+func (entry *Entry) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type EntryAlias Entry
+	if err := d.DecodeElement((*EntryAlias)(entry), &start); err != nil {
+		return err
+	}
+	entry.inlineValue.parse(entry.Value)
+	return nil
+}
+
+// UnmarshalXML decodes the Event, and parses its Value for Inline()
+func (event *Event) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type EventAlias Event
+	if err := d.DecodeElement((*EventAlias)(event), &start); err != nil {
+		return err
+	}
+	event.inlineValue.parse(event.Value)
+	return nil
+}
+
+// UnmarshalXML decodes the Head, and parses its Value for Inline()
+func (head *Head) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type HeadAlias Head
+	if err := d.DecodeElement((*HeadAlias)(head), &start); err != nil {
+		return err
+	}
+	head.inlineValue.parse(head.Value)
+	return nil
+}
+
+// UnmarshalXML decodes the Item, and parses its Value for Inline()
+func (item *Item) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type ItemAlias Item
+	if err := d.DecodeElement((*ItemAlias)(item), &start); err != nil {
+		return err
+	}
+	item.inlineValue.parse(item.Value)
+	return nil
+}
+
+// UnmarshalXML decodes the LangMaterial, and parses its Value for Inline()
+func (langmaterial *LangMaterial) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type LangMaterialAlias LangMaterial
+	if err := d.DecodeElement((*LangMaterialAlias)(langmaterial), &start); err != nil {
+		return err
+	}
+	langmaterial.inlineValue.parse(langmaterial.Value)
+	return nil
+}
+
+// UnmarshalXML decodes the LangUsage, and parses its Value for Inline()
+func (langusage *LangUsage) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type LangUsageAlias LangUsage
+	if err := d.DecodeElement((*LangUsageAlias)(langusage), &start); err != nil {
+		return err
+	}
+	langusage.inlineValue.parse(langusage.Value)
+	return nil
+}
+
+// UnmarshalXML decodes the LegalStatus, and parses its Value for Inline()
+func (legalstatus *LegalStatus) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type LegalStatusAlias LegalStatus
+	if err := d.DecodeElement((*LegalStatusAlias)(legalstatus), &start); err != nil {
+		return err
+	}
+	legalstatus.inlineValue.parse(legalstatus.Value)
+	return nil
+}
+
+// UnmarshalXML decodes the Num, and parses its Value for Inline()
+func (num *Num) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type NumAlias Num
+	if err := d.DecodeElement((*NumAlias)(num), &start); err != nil {
+		return err
+	}
+	num.inlineValue.parse(num.Value)
+	return nil
+}
+
+// UnmarshalXML decodes the P, and parses its Value for Inline()
+func (p *P) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type PAlias P
+	if err := d.DecodeElement((*PAlias)(p), &start); err != nil {
+		return err
+	}
+	p.inlineValue.parse(p.Value)
+	return nil
+}
+
+// UnmarshalXML decodes the PhysDesc, and parses its Value for Inline()
+func (physdesc *PhysDesc) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type PhysDescAlias PhysDesc
+	if err := d.DecodeElement((*PhysDescAlias)(physdesc), &start); err != nil {
+		return err
+	}
+	physdesc.inlineValue.parse(physdesc.Value)
+	return nil
+}
+
+// UnmarshalXML decodes the PhysFacet, and parses its Value for Inline()
+func (physfacet *PhysFacet) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type PhysFacetAlias PhysFacet
+	if err := d.DecodeElement((*PhysFacetAlias)(physfacet), &start); err != nil {
+		return err
+	}
+	physfacet.inlineValue.parse(physfacet.Value)
+	return nil
+}
+
+// UnmarshalXML decodes the PhysLoc, and parses its Value for Inline()
+func (physloc *PhysLoc) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type PhysLocAlias PhysLoc
+	if err := d.DecodeElement((*PhysLocAlias)(physloc), &start); err != nil {
+		return err
+	}
+	physloc.inlineValue.parse(physloc.Value)
+	return nil
+}
+
+// UnmarshalXML decodes the Repository, and parses its Value for Inline()
+func (repository *Repository) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type RepositoryAlias Repository
+	if err := d.DecodeElement((*RepositoryAlias)(repository), &start); err != nil {
+		return err
+	}
+	repository.inlineValue.parse(repository.Value)
+	return nil
+}
+
+// UnmarshalXML decodes the Title, and parses its Value for Inline()
+func (title *Title) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type TitleAlias Title
+	if err := d.DecodeElement((*TitleAlias)(title), &start); err != nil {
+		return err
+	}
+	title.inlineValue.parse(title.Value)
+	return nil
+}
+
+// UnmarshalXML decodes the UnitTitle, and parses its Value for Inline()
+func (unittitle *UnitTitle) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type UnitTitleAlias UnitTitle
+	if err := d.DecodeElement((*UnitTitleAlias)(unittitle), &start); err != nil {
+		return err
+	}
+	unittitle.inlineValue.parse(unittitle.Value)
+	return nil
+}
+
+// Inline returns the mixed content of the Abstract Value.  The Value is
+// parsed once when the Abstract is decoded, or on each call if the Value
+// was not decoded or has been changed since.  The InlineContent is shared, so
+// it must not be modified.
+func (abstract *Abstract) Inline() (InlineContent, error) {
+	return abstract.inlineValue.inline(abstract.Value)
+}
+
+// Inline returns the mixed content of the AddressLine Value.  The Value is
+// parsed once when the AddressLine is decoded, or on each call if the Value
+// was not decoded or has been changed since.  The InlineContent is shared, so
+// it must not be modified.
+func (addressline *AddressLine) Inline() (InlineContent, error) {
+	return addressline.inlineValue.inline(addressline.Value)
+}
+
+// Inline returns the mixed content of the ArchRef Value.  The Value is
+// parsed once when the ArchRef is decoded, or on each call if the Value
+// was not decoded or has been changed since.  The InlineContent is shared, so
+// it must not be modified.
+func (archref *ArchRef) Inline() (InlineContent, error) {
+	return archref.inlineValue.inline(archref.Value)
+}
+
+// Inline returns the mixed content of the BibRef Value.  The Value is
+// parsed once when the BibRef is decoded, or on each call if the Value
+// was not decoded or has been changed since.  The InlineContent is shared, so
+// it must not be modified.
+func (bibref *BibRef) Inline() (InlineContent, error) {
+	return bibref.inlineValue.inline(bibref.Value)
+}
+
+// Inline returns the mixed content of the ChronItem Value.  The Value is
+// parsed once when the ChronItem is decoded, or on each call if the Value
+// was not decoded or has been changed since.  The InlineContent is shared, so
+// it must not be modified.
+func (chronitem *ChronItem) Inline() (InlineContent, error) {
+	return chronitem.inlineValue.inline(chronitem.Value)
+}
+
+// Inline returns the mixed content of the Container Value.  The Value is
+// parsed once when the Container is decoded, or on each call if the Value
+// was not decoded or has been changed since.  The InlineContent is shared, so
+// it must not be modified.
+func (container *Container) Inline() (InlineContent, error) {
+	return container.inlineValue.inline(container.Value)
+}
+
+// Inline returns the mixed content of the Creation Value.  The Value is
+// parsed once when the Creation is decoded, or on each call if the Value
+// was not decoded or has been changed since.  The InlineContent is shared, so
+// it must not be modified.
+func (creation *Creation) Inline() (InlineContent, error) {
+	return creation.inlineValue.inline(creation.Value)
+}
+
+// Inline returns the mixed content of the Date Value.  The Value is
+// parsed once when the Date is decoded, or on each call if the Value
+// was not decoded or has been changed since.  The InlineContent is shared, so
+// it must not be modified.
+func (date *Date) Inline() (InlineContent, error) {
+	return date.inlineValue.inline(date.Value)
+}
+
+// Inline returns the mixed content of the Dimensions Value.  The Value is
+// parsed once when the Dimensions is decoded, or on each call if the Value
+// was not decoded or has been changed since.  The InlineContent is shared, so
+// it must not be modified.
+func (dimensions *Dimensions) Inline() (InlineContent, error) {
+	return dimensions.inlineValue.inline(dimensions.Value)
+}
+
+// Inline returns the mixed content of the Entry Value.  The Value is
+// parsed once when the Entry is decoded, or on each call if the Value
+// was not decoded or has been changed since.  The InlineContent is shared, so
+// it must not be modified.
+func (entry *Entry) Inline() (InlineContent, error) {
+	return entry.inlineValue.inline(entry.Value)
+}
+
+// Inline returns the mixed content of the Event Value.  The Value is
+// parsed once when the Event is decoded, or on each call if the Value
+// was not decoded or has been changed since.  The InlineContent is shared, so
+// it must not be modified.
+func (event *Event) Inline() (InlineContent, error) {
+	return event.inlineValue.inline(event.Value)
+}
+
+// Inline returns the mixed content of the Head Value.  The Value is
+// parsed once when the Head is decoded, or on each call if the Value
+// was not decoded or has been changed since.  The InlineContent is shared, so
+// it must not be modified.
+func (head *Head) Inline() (InlineContent, error) {
+	return head.inlineValue.inline(head.Value)
+}
+
+// Inline returns the mixed content of the Item Value.  The Value is
+// parsed once when the Item is decoded, or on each call if the Value
+// was not decoded or has been changed since.  The InlineContent is shared, so
+// it must not be modified.
+func (item *Item) Inline() (InlineContent, error) {
+	return item.inlineValue.inline(item.Value)
+}
+
+// Inline returns the mixed content of the LangMaterial Value.  The Value is
+// parsed once when the LangMaterial is decoded, or on each call if the Value
+// was not decoded or has been changed since.  The InlineContent is shared, so
+// it must not be modified.
+func (langmaterial *LangMaterial) Inline() (InlineContent, error) {
+	return langmaterial.inlineValue.inline(langmaterial.Value)
+}
+
+// Inline returns the mixed content of the LangUsage Value.  The Value is
+// parsed once when the LangUsage is decoded, or on each call if the Value
+// was not decoded or has been changed since.  The InlineContent is shared, so
+// it must not be modified.
+func (langusage *LangUsage) Inline() (InlineContent, error) {
+	return langusage.inlineValue.inline(langusage.Value)
+}
+
+// Inline returns the mixed content of the LegalStatus Value.  The Value is
+// parsed once when the LegalStatus is decoded, or on each call if the Value
+// was not decoded or has been changed since.  The InlineContent is shared, so
+// it must not be modified.
+func (legalstatus *LegalStatus) Inline() (InlineContent, error) {
+	return legalstatus.inlineValue.inline(legalstatus.Value)
+}
+
+// Inline returns the mixed content of the Num Value.  The Value is
+// parsed once when the Num is decoded, or on each call if the Value
+// was not decoded or has been changed since.  The InlineContent is shared, so
+// it must not be modified.
+func (num *Num) Inline() (InlineContent, error) {
+	return num.inlineValue.inline(num.Value)
+}
+
+// Inline returns the mixed content of the P Value.  The Value is
+// parsed once when the P is decoded, or on each call if the Value
+// was not decoded or has been changed since.  The InlineContent is shared, so
+// it must not be modified.
+func (p *P) Inline() (InlineContent, error) {
+	return p.inlineValue.inline(p.Value)
+}
+
+// Inline returns the mixed content of the PhysDesc Value.  The Value is
+// parsed once when the PhysDesc is decoded, or on each call if the Value
+// was not decoded or has been changed since.  The InlineContent is shared, so
+// it must not be modified.
+func (physdesc *PhysDesc) Inline() (InlineContent, error) {
+	return physdesc.inlineValue.inline(physdesc.Value)
+}
+
+// Inline returns the mixed content of the PhysFacet Value.  The Value is
+// parsed once when the PhysFacet is decoded, or on each call if the Value
+// was not decoded or has been changed since.  The InlineContent is shared, so
+// it must not be modified.
+func (physfacet *PhysFacet) Inline() (InlineContent, error) {
+	return physfacet.inlineValue.inline(physfacet.Value)
+}
+
+// Inline returns the mixed content of the PhysLoc Value.  The Value is
+// parsed once when the PhysLoc is decoded, or on each call if the Value
+// was not decoded or has been changed since.  The InlineContent is shared, so
+// it must not be modified.
+func (physloc *PhysLoc) Inline() (InlineContent, error) {
+	return physloc.inlineValue.inline(physloc.Value)
+}
+
+// Inline returns the mixed content of the Repository Value.  The Value is
+// parsed once when the Repository is decoded, or on each call if the Value
+// was not decoded or has been changed since.  The InlineContent is shared, so
+// it must not be modified.
+func (repository *Repository) Inline() (InlineContent, error) {
+	return repository.inlineValue.inline(repository.Value)
+}
+
+// Inline returns the mixed content of the Title Value.  The Value is
+// parsed once when the Title is decoded, or on each call if the Value
+// was not decoded or has been changed since.  The InlineContent is shared, so
+// it must not be modified.
+func (title *Title) Inline() (InlineContent, error) {
+	return title.inlineValue.inline(title.Value)
+}
+
+// Inline returns the mixed content of the UnitTitle Value.  The Value is
+// parsed once when the UnitTitle is decoded, or on each call if the Value
+// was not decoded or has been changed since.  The InlineContent is shared, so
+// it must not be modified.
+func (unittitle *UnitTitle) Inline() (InlineContent, error) {
+	return unittitle.inlineValue.inline(unittitle.Value)
+}
+
+// PlainText renders the Abstract Value as plain text, see InlineContent.PlainText().
+// A Value that is not well-formed is rendered with the tags removed.
+func (abstract *Abstract) PlainText() string {
+	content, err := abstract.Inline()
+	if err != nil {
+		return getPlainText(abstract.Value)
+	}
+	return content.PlainText()
+}
+
+// PlainText renders the AddressLine Value as plain text, see InlineContent.PlainText().
+// A Value that is not well-formed is rendered with the tags removed.
+func (addressline *AddressLine) PlainText() string {
+	content, err := addressline.Inline()
+	if err != nil {
+		return getPlainText(addressline.Value)
+	}
+	return content.PlainText()
+}
+
+// PlainText renders the ArchRef Value as plain text, see InlineContent.PlainText().
+// A Value that is not well-formed is rendered with the tags removed.
+func (archref *ArchRef) PlainText() string {
+	content, err := archref.Inline()
+	if err != nil {
+		return getPlainText(archref.Value)
+	}
+	return content.PlainText()
+}
+
+// PlainText renders the BibRef Value as plain text, see InlineContent.PlainText().
+// A Value that is not well-formed is rendered with the tags removed.
+func (bibref *BibRef) PlainText() string {
+	content, err := bibref.Inline()
+	if err != nil {
+		return getPlainText(bibref.Value)
+	}
+	return content.PlainText()
+}
+
+// PlainText renders the ChronItem Value as plain text, see InlineContent.PlainText().
+// A Value that is not well-formed is rendered with the tags removed.
+func (chronitem *ChronItem) PlainText() string {
+	content, err := chronitem.Inline()
+	if err != nil {
+		return getPlainText(chronitem.Value)
+	}
+	return content.PlainText()
+}
+
+// PlainText renders the Container Value as plain text, see InlineContent.PlainText().
+// A Value that is not well-formed is rendered with the tags removed.
+func (container *Container) PlainText() string {
+	content, err := container.Inline()
+	if err != nil {
+		return getPlainText(container.Value)
+	}
+	return content.PlainText()
+}
+
+// PlainText renders the Creation Value as plain text, see InlineContent.PlainText().
+// A Value that is not well-formed is rendered with the tags removed.
+func (creation *Creation) PlainText() string {
+	content, err := creation.Inline()
+	if err != nil {
+		return getPlainText(creation.Value)
+	}
+	return content.PlainText()
+}
+
+// PlainText renders the Date Value as plain text, see InlineContent.PlainText().
+// A Value that is not well-formed is rendered with the tags removed.
+func (date *Date) PlainText() string {
+	content, err := date.Inline()
+	if err != nil {
+		return getPlainText(date.Value)
+	}
+	return content.PlainText()
+}
+
+// PlainText renders the Dimensions Value as plain text, see InlineContent.PlainText().
+// A Value that is not well-formed is rendered with the tags removed.
+func (dimensions *Dimensions) PlainText() string {
+	content, err := dimensions.Inline()
+	if err != nil {
+		return getPlainText(dimensions.Value)
+	}
+	return content.PlainText()
+}
+
+// PlainText renders the Entry Value as plain text, see InlineContent.PlainText().
+// A Value that is not well-formed is rendered with the tags removed.
+func (entry *Entry) PlainText() string {
+	content, err := entry.Inline()
+	if err != nil {
+		return getPlainText(entry.Value)
+	}
+	return content.PlainText()
+}
+
+// PlainText renders the Event Value as plain text, see InlineContent.PlainText().
+// A Value that is not well-formed is rendered with the tags removed.
+func (event *Event) PlainText() string {
+	content, err := event.Inline()
+	if err != nil {
+		return getPlainText(event.Value)
+	}
+	return content.PlainText()
+}
+
+// PlainText renders the Head Value as plain text, see InlineContent.PlainText().
+// A Value that is not well-formed is rendered with the tags removed.
+func (head *Head) PlainText() string {
+	content, err := head.Inline()
+	if err != nil {
+		return getPlainText(head.Value)
+	}
+	return content.PlainText()
+}
+
+// PlainText renders the Item Value as plain text, see InlineContent.PlainText().
+// A Value that is not well-formed is rendered with the tags removed.
+func (item *Item) PlainText() string {
+	content, err := item.Inline()
+	if err != nil {
+		return getPlainText(item.Value)
+	}
+	return content.PlainText()
+}
+
+// PlainText renders the LangMaterial Value as plain text, see InlineContent.PlainText().
+// A Value that is not well-formed is rendered with the tags removed.
+func (langmaterial *LangMaterial) PlainText() string {
+	content, err := langmaterial.Inline()
+	if err != nil {
+		return getPlainText(langmaterial.Value)
+	}
+	return content.PlainText()
+}
+
+// PlainText renders the LangUsage Value as plain text, see InlineContent.PlainText().
+// A Value that is not well-formed is rendered with the tags removed.
+func (langusage *LangUsage) PlainText() string {
+	content, err := langusage.Inline()
+	if err != nil {
+		return getPlainText(langusage.Value)
+	}
+	return content.PlainText()
+}
+
+// PlainText renders the LegalStatus Value as plain text, see InlineContent.PlainText().
+// A Value that is not well-formed is rendered with the tags removed.
+func (legalstatus *LegalStatus) PlainText() string {
+	content, err := legalstatus.Inline()
+	if err != nil {
+		return getPlainText(legalstatus.Value)
+	}
+	return content.PlainText()
+}
+
+// PlainText renders the Num Value as plain text, see InlineContent.PlainText().
+// A Value that is not well-formed is rendered with the tags removed.
+func (num *Num) PlainText() string {
+	content, err := num.Inline()
+	if err != nil {
+		return getPlainText(num.Value)
+	}
+	return content.PlainText()
+}
+
+// PlainText renders the P Value as plain text, see InlineContent.PlainText().
+// A Value that is not well-formed is rendered with the tags removed.
+func (p *P) PlainText() string {
+	content, err := p.Inline()
+	if err != nil {
+		return getPlainText(p.Value)
+	}
+	return content.PlainText()
+}
+
+// PlainText renders the PhysDesc Value as plain text, see InlineContent.PlainText().
+// A Value that is not well-formed is rendered with the tags removed.
+func (physdesc *PhysDesc) PlainText() string {
+	content, err := physdesc.Inline()
+	if err != nil {
+		return getPlainText(physdesc.Value)
+	}
+	return content.PlainText()
+}
+
+// PlainText renders the PhysFacet Value as plain text, see InlineContent.PlainText().
+// A Value that is not well-formed is rendered with the tags removed.
+func (physfacet *PhysFacet) PlainText() string {
+	content, err := physfacet.Inline()
+	if err != nil {
+		return getPlainText(physfacet.Value)
+	}
+	return content.PlainText()
+}
+
+// PlainText renders the PhysLoc Value as plain text, see InlineContent.PlainText().
+// A Value that is not well-formed is rendered with the tags removed.
+func (physloc *PhysLoc) PlainText() string {
+	content, err := physloc.Inline()
+	if err != nil {
+		return getPlainText(physloc.Value)
+	}
+	return content.PlainText()
+}
+
+// PlainText renders the Repository Value as plain text, see InlineContent.PlainText().
+// A Value that is not well-formed is rendered with the tags removed.
+func (repository *Repository) PlainText() string {
+	content, err := repository.Inline()
+	if err != nil {
+		return getPlainText(repository.Value)
+	}
+	return content.PlainText()
+}
+
+// PlainText renders the Title Value as plain text, see InlineContent.PlainText().
+// A Value that is not well-formed is rendered with the tags removed.
+func (title *Title) PlainText() string {
+	content, err := title.Inline()
+	if err != nil {
+		return getPlainText(title.Value)
+	}
+	return content.PlainText()
+}
+
+// PlainText renders the UnitTitle Value as plain text, see InlineContent.PlainText().
+// A Value that is not well-formed is rendered with the tags removed.
+func (unittitle *UnitTitle) PlainText() string {
+	content, err := unittitle.Inline()
+	if err != nil {
+		return getPlainText(unittitle.Value)
+	}
+	return content.PlainText()
+}
@@ -72,7 +72,7 @@ func (accessTermWithRole *AccessTermWithRole) MarshalJSON() ([]byte, error) {
 		}
 	}
 
-	result, err := getConvertedTextWithTags(accessTermWithRole.Value)
+	result, err := accessTermWithRole.htmlRenderer().convertTextWithTags(accessTermWithRole.Value)
 	if err != nil {
 		return nil, err
 	}
@@ -95,7 +95,7 @@ func (accessTermWithRole *AccessTermWithRole) MarshalJSON() ([]byte, error) {
 func (titleproper *TitleProper) MarshalJSON() ([]byte, error) {
 	type TitleProperWithTags TitleProper
 
-	result, err := getConvertedTextWithTagsNoLBConversion(titleproper.Value)
+	result, err := titleproper.htmlRenderer().convertTextWithTagsNoLBConversion(titleproper.Value)
 	if err != nil {
 		return nil, err
 	}
@@ -139,16 +139,16 @@ func (titleStmt *TitleStmt) MarshalJSON() ([]byte, error) {
 
 	return json.Marshal(&struct {
 		*TitleStmtAlias
-		FlattenedAuthor      FilteredString `json:"author,omitempty"`
-		FlattenedSponsor     FilteredString `json:"sponsor,omitempty"`
-		FlattenedSubTitle    FilteredString `json:"subtitle,omitempty"`
-		FlattenedTitleProper FilteredString `json:"titleproper,omitempty"`
+		FlattenedAuthor      string `json:"author,omitempty"`
+		FlattenedSponsor     string `json:"sponsor,omitempty"`
+		FlattenedSubTitle    string `json:"subtitle,omitempty"`
+		FlattenedTitleProper string `json:"titleproper,omitempty"`
 	}{
 		TitleStmtAlias:       (*TitleStmtAlias)(titleStmt),
-		FlattenedAuthor:      FilteredString(flattenedAuthor),
-		FlattenedSponsor:     FilteredString(flattenedSponsor),
-		FlattenedSubTitle:    FilteredString(flattenedSubTitle),
-		FlattenedTitleProper: FilteredString(flattenedTitleProper),
+		FlattenedAuthor:      string(flattenedAuthor),
+		FlattenedSponsor:     string(flattenedSponsor),
+		FlattenedSubTitle:    string(flattenedSubTitle),
+		FlattenedTitleProper: string(flattenedTitleProper),
 	})
 }
 
@@ -162,10 +162,10 @@ func (indexEntry *IndexEntry) MarshalJSON() ([]byte, error) {
 
 	return json.Marshal(&struct {
 		*IndexEntryAlias
-		FlattenedRef FilteredString `json:"ref,omitempty"`
+		FlattenedRef string `json:"ref,omitempty"`
 	}{
 		IndexEntryAlias: (*IndexEntryAlias)(indexEntry),
-		FlattenedRef:    FilteredString(flattenedRef),
+		FlattenedRef:    string(flattenedRef),
 	})
 }
 
@@ -179,7 +179,7 @@ func (extent *Extent) MarshalJSON() ([]byte, error) {
 		extent.Value = extent.Value + " " + extent.Unit.String()
 	}
 
-	result, err := getConvertedTextWithTags(extent.Value)
+	result, err := extent.htmlRenderer().convertTextWithTags(extent.Value)
 	if err != nil {
 		return nil, err
 	}
@@ -207,7 +207,7 @@ func (fnwh *FormattedNoteWithHead) MarshalJSON() ([]byte, error) {
 	// if there are no children then create a child from innerxml...
 	if len(fnwh.Children) == 0 {
 		// Children array is empty, therefore flatten innerXML
-		flattenedValue, err := getConvertedTextWithTags(fnwh.Value)
+		flattenedValue, err := fnwh.htmlRenderer().convertTextWithTags(fnwh.Value)
 		if err != nil {
 			return nil, err
 		}
@@ -218,9 +218,9 @@ func (fnwh *FormattedNoteWithHead) MarshalJSON() ([]byte, error) {
 		child := EADChild{}
 		child.Name = "div"
 		child.Value = &struct {
-			Value FilteredString `json:"value,omitempty"`
+			Value string `json:"value,omitempty"`
 		}{
-			Value: FilteredString(flattenedValue),
+			Value: string(flattenedValue),
 		}
 		fnwh.Children = append(fnwh.Children, &child)
 	}
@@ -231,3 +231,70 @@ func (fnwh *FormattedNoteWithHead) MarshalJSON() ([]byte, error) {
 		FormattedNoteWithHeadAlias: (*FormattedNoteWithHeadAlias)(fnwh),
 	})
 }
+
+// RawElement requires custom marshaling to output the attributes by local
+// name, and the content as HTML
+func (raw *RawElement) MarshalJSON() ([]byte, error) {
+	attributes := map[string]string{}
+	for _, attr := range raw.Attr {
+		attributes[attr.Name.Local] = attr.Value
+	}
+
+	result, err := raw.htmlRenderer().convertTextWithTags(raw.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&struct {
+		Attributes map[string]string `json:"attributes,omitempty"`
+		Value      string            `json:"value,omitempty"`
+	}{
+		Attributes: attributes,
+		Value:      string(result),
+	})
+}
+
+// Table requires custom marshaling to add the HTML rendering of the table
+// alongside the table elements
+func (table *Table) MarshalJSON() ([]byte, error) {
+	type TableAlias Table
+
+	result, err := table.HTML()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&struct {
+		Value string `json:"value,omitempty"`
+		*TableAlias
+	}{
+		Value:      result,
+		TableAlias: (*TableAlias)(table),
+	})
+}
+
+// UnitDate requires custom marshaling to add the parsed date range
+// alongside the display value
+func (unitdate *UnitDate) MarshalJSON() ([]byte, error) {
+	type UnitDateWithTags UnitDate
+
+	result, err := unitdate.htmlRenderer().convertTextWithTags(unitdate.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData, err := json.Marshal(&struct {
+		Value string `json:"value,omitempty"`
+		*UnitDateWithTags
+		DateRange *DateRange `json:"daterange,omitempty"`
+	}{
+		Value:            string(result),
+		UnitDateWithTags: (*UnitDateWithTags)(unitdate),
+		DateRange:        unitdate.DateRange(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonData, nil
+}
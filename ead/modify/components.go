@@ -0,0 +1,125 @@
+package modify
+
+import (
+	"fmt"
+
+	"github.com/lestrrat-go/libxml2/clib"
+	"github.com/lestrrat-go/libxml2/parser"
+	"github.com/lestrrat-go/libxml2/types"
+	"github.com/nyulibraries/dlts-finding-aids-ead-go-packages/ead"
+)
+
+// MaxComponentLevel is the deepest level of the EAD 2002 numbered components, <c12>
+const MaxComponentLevel = 12
+
+// NumberComponents renames the components of an EAD []byte slice to numbered
+// components: the top-level components of the <dsc> to <c01>, their child
+// components to <c02>, and so on.  Both <c> and numbered components are
+// renamed, so EADs with incorrectly numbered components are renumbered.
+//
+// If the component hierarchy is deeper than <c12>, the EAD is not renamed:
+// the returned string is empty, and the returned []string describes the
+// problem.
+func NumberComponents(data []byte) (string, []string) {
+	return renameComponents(data, func(level int) (string, error) {
+		if level > MaxComponentLevel {
+			return "", fmt.Errorf("component level %d is deeper than <c%02d>", level, MaxComponentLevel)
+		}
+		return fmt.Sprintf("c%02d", level), nil
+	})
+}
+
+// UnnumberComponents renames the numbered components, <c01> to <c12>, of an
+// EAD []byte slice to <c>
+func UnnumberComponents(data []byte) (string, []string) {
+	return renameComponents(data, func(level int) (string, error) {
+		return "c", nil
+	})
+}
+
+func renameComponents(data []byte, componentName func(level int) (string, error)) (string, []string) {
+	var errors = []string{}
+
+	p := parser.New()
+	doc, err := p.Parse(data)
+	if err != nil {
+		errors = append(errors, "Unable to parse XML file")
+		return "", append(errors, err.Error())
+	}
+	defer doc.Free()
+
+	root, err := doc.DocumentElement()
+	if err != nil {
+		errors = append(errors, "Unable to extract root node")
+		return "", append(errors, err.Error())
+	}
+
+	err = renameComponentsInTree(root, componentName)
+	if err != nil {
+		errors = append(errors, "Unable to rename components")
+		return "", append(errors, err.Error())
+	}
+
+	return doc.String(), errors
+}
+
+// renameComponentsInTree finds the <dsc> elements of the tree, and renames
+// their components
+func renameComponentsInTree(node types.Node, componentName func(level int) (string, error)) error {
+	children, err := childElements(node)
+	if err != nil {
+		return err
+	}
+
+	for _, child := range children {
+		if child.LocalName() == "dsc" {
+			err = renameChildComponents(child, 1, componentName)
+		} else {
+			err = renameComponentsInTree(child, componentName)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func renameChildComponents(node types.Node, level int, componentName func(level int) (string, error)) error {
+	children, err := childElements(node)
+	if err != nil {
+		return err
+	}
+
+	for _, child := range children {
+		if !ead.IsComponentName(child.LocalName()) {
+			continue
+		}
+
+		name, err := componentName(level)
+		if err != nil {
+			return err
+		}
+		child.SetNodeName(name)
+
+		err = renameChildComponents(child, level+1, componentName)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func childElements(node types.Node) ([]types.Element, error) {
+	children, err := node.ChildNodes()
+	if err != nil {
+		return nil, err
+	}
+
+	var elements []types.Element
+	for _, child := range children {
+		if element, ok := child.(types.Element); ok && child.NodeType() == clib.ElementNode {
+			elements = append(elements, element)
+		}
+	}
+	return elements, nil
+}
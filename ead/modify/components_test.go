@@ -0,0 +1,68 @@
+package modify
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func runComponentsTest(t *testing.T, modifyFunc func([]byte) (string, []string), sourceFile string, referenceFile string) {
+	testFixturePath := filepath.Join(".", "testdata")
+	testTmpDirPath := filepath.Join(testFixturePath, "tmp")
+
+	EADXML, err := os.ReadFile(filepath.Join(testFixturePath, sourceFile))
+	failOnError(t, err, "Unexpected error")
+
+	doc, errors := modifyFunc(EADXML)
+	if len(errors) != 0 {
+		failOnError(t, fmt.Errorf("%s", strings.Join(errors, "\n")), "problem modifying EAD")
+	}
+
+	want, err := os.ReadFile(filepath.Join(testFixturePath, referenceFile))
+	failOnError(t, err, "Unexpected error reading reference file")
+
+	if string(want) != doc {
+		errTmpFile := filepath.Join(testTmpDirPath, "ERR-"+referenceFile)
+		err = os.WriteFile(errTmpFile, []byte(doc), 0644)
+		failOnError(t, err, fmt.Sprintf("Unexpected error writing %s", errTmpFile))
+
+		t.Errorf("The modified EAD does not match the reference file.\ndiff %s %s", errTmpFile, filepath.Join(testFixturePath, referenceFile))
+	}
+}
+
+func TestNumberComponents(t *testing.T) {
+	t.Run("Number Components", func(t *testing.T) {
+		runComponentsTest(t, NumberComponents, "components-unnumbered.xml", "components-numbered.xml")
+	})
+
+	t.Run("Renumber Components", func(t *testing.T) {
+		runComponentsTest(t, NumberComponents, "components-numbered.xml", "components-numbered.xml")
+	})
+
+	t.Run("Component Hierarchy Deeper Than c12", func(t *testing.T) {
+		EADXML := `<ead><archdesc><dsc>` + strings.Repeat("<c>", 13) + strings.Repeat("</c>", 13) + `</dsc></archdesc></ead>`
+		_, errors := NumberComponents([]byte(EADXML))
+		if len(errors) == 0 || !strings.Contains(strings.Join(errors, "\n"), "component level 13 is deeper than <c12>") {
+			t.Errorf("Expected a component level error, got %v", errors)
+		}
+	})
+}
+
+func TestUnnumberComponents(t *testing.T) {
+	t.Run("Unnumber Components", func(t *testing.T) {
+		runComponentsTest(t, UnnumberComponents, "components-numbered.xml", "components-unnumbered.xml")
+	})
+
+	t.Run("Unnumbered Components", func(t *testing.T) {
+		runComponentsTest(t, UnnumberComponents, "components-unnumbered.xml", "components-unnumbered.xml")
+	})
+
+	t.Run("Invalid XML", func(t *testing.T) {
+		_, errors := UnnumberComponents([]byte("This is not XML!"))
+		if len(errors) == 0 {
+			t.Errorf("Expected an error parsing invalid XML")
+		}
+	})
+}
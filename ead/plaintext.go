@@ -0,0 +1,198 @@
+package ead
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PlainTextIndent is the indentation of each level of nested lists in
+// plain text renderings
+const PlainTextIndent = "  "
+
+// PlainText renders the inline content as text without markup, e.g., for
+// search indexing, CSV reports, and email notifications:
+//   - whitespace is cleaned up, and <lb/> starts a new line
+//   - each list item, chronlist item, and definition list item is rendered
+//     on its own line, e.g., "- item", "1. item", "1920: event; event",
+//     and "label: item"
+//   - <extref> and <extptr> links are rendered as the text followed by the
+//     URL in brackets, e.g., "the website [https://example.org]"
+//   - the <extent> @unit is appended to the extent
+func (ic InlineContent) PlainText() string {
+	w := &plainTextWriter{}
+	w.writeContent(ic)
+	return w.String()
+}
+
+// plainTextWriter collects the lines of a plain text rendering
+type plainTextWriter struct {
+	lines []string
+	line  strings.Builder
+	// indent is the indentation of the current list level
+	indent string
+	// prefix is prepended to the current line, e.g., "  - "
+	prefix string
+}
+
+// newline ends the current line.  Lines without text are dropped.
+func (w *plainTextWriter) newline() {
+	text := strings.Join(strings.Fields(w.line.String()), " ")
+	if text != "" {
+		w.lines = append(w.lines, w.prefix+text)
+	}
+	w.line.Reset()
+	w.prefix = w.indent
+}
+
+// startLine ends the current line and starts a new line with the prefix
+func (w *plainTextWriter) startLine(prefix string) {
+	w.newline()
+	w.prefix = w.indent + prefix
+}
+
+func (w *plainTextWriter) String() string {
+	w.newline()
+	return strings.Join(w.lines, "\n")
+}
+
+func (w *plainTextWriter) writeContent(ic InlineContent) {
+	for _, node := range ic {
+		switch node.Type {
+		case InlineTextNode:
+			w.line.WriteString(node.Text)
+		case InlineElementNode:
+			w.writeElement(node)
+		}
+	}
+}
+
+func (w *plainTextWriter) writeElement(node *InlineNode) {
+	switch node.LocalName() {
+	case "lb":
+		w.newline()
+	case "head", "p", "blockquote", "address", "addressline":
+		w.newline()
+		w.writeContent(node.Children)
+		w.newline()
+	case "list":
+		w.writeList(node)
+	case "chronlist":
+		w.writeChronList(node)
+	case "extref", "extptr":
+		text := node.Children.PlainText()
+		href := node.Attribute("href")
+		w.writeContent(node.Children)
+		if href != "" && href != text {
+			if text != "" {
+				w.line.WriteString(" ")
+			}
+			w.line.WriteString("[" + href + "]")
+		}
+	case "extent":
+		w.writeContent(node.Children)
+		if unit := node.Attribute("unit"); unit != "" {
+			w.line.WriteString(" " + unit)
+		}
+	default:
+		w.writeContent(node.Children)
+	}
+}
+
+func (w *plainTextWriter) writeList(list *InlineNode) {
+	ordered := list.Attribute("type") == "ordered"
+
+	w.newline()
+	parentIndent := w.indent
+	var number int
+	for _, child := range list.Children {
+		if child.Type != InlineElementNode {
+			continue
+		}
+
+		switch child.LocalName() {
+		case "head":
+			w.startLine("")
+			w.writeContent(child.Children)
+		case "item":
+			number++
+			if ordered {
+				w.startLine(fmt.Sprintf("%d. ", number))
+			} else {
+				w.startLine("- ")
+			}
+			w.indent = parentIndent + PlainTextIndent
+			w.writeContent(child.Children)
+			w.indent = parentIndent
+		case "defitem":
+			w.startLine("")
+			w.writeDefItem(child)
+		}
+	}
+	w.newline()
+}
+
+func (w *plainTextWriter) writeDefItem(defItem *InlineNode) {
+	var label, item string
+	for _, child := range defItem.Children {
+		switch child.LocalName() {
+		case "label":
+			label = child.Children.PlainText()
+		case "item":
+			item = child.Children.PlainText()
+		}
+	}
+
+	if label != "" && item != "" {
+		w.line.WriteString(label + ": " + item)
+	} else {
+		w.line.WriteString(label + item)
+	}
+}
+
+func (w *plainTextWriter) writeChronList(chronList *InlineNode) {
+	w.newline()
+	for _, child := range chronList.Children {
+		switch child.LocalName() {
+		case "head":
+			w.startLine("")
+			w.writeContent(child.Children)
+		case "chronitem":
+			w.startLine("")
+			w.writeChronItem(child)
+		}
+	}
+	w.newline()
+}
+
+func (w *plainTextWriter) writeChronItem(chronItem *InlineNode) {
+	var date string
+	var events []string
+	for _, child := range chronItem.Children {
+		switch child.LocalName() {
+		case "date":
+			date = child.Children.PlainText()
+		case "event":
+			events = append(events, child.Children.PlainText())
+		case "eventgrp":
+			for _, event := range child.Children.Elements("event") {
+				events = append(events, event.Children.PlainText())
+			}
+		}
+	}
+
+	if date != "" && len(events) > 0 {
+		w.line.WriteString(date + ": " + strings.Join(events, "; "))
+	} else {
+		w.line.WriteString(date + strings.Join(events, "; "))
+	}
+}
+
+// getInlinePlainText renders the mixed content value as plain text, falling
+// back to removing the tags if the value is not well-formed
+func getInlinePlainText(value string) string {
+	content, err := ParseInlineContent(value)
+	if err != nil {
+		return getPlainText(value)
+	}
+	return content.PlainText()
+}
@@ -0,0 +1,69 @@
+package ead
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInlineContentPlainText(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"Whitespace", "  Letters\n\t from   <emph render=\"bold\">Jane</emph>  ", "Letters from Jane"},
+		{"Line Breaks", "123 Main St.<lb/>Springfield,<lb/><lb/>\n  USA", "123 Main St.\nSpringfield,\nUSA"},
+		{"Entities", "Tom &amp; Jerry &lt;3", "Tom & Jerry <3"},
+		{"ExtRef", `See <extref href="https://example.org">the website</extref>.`, "See the website [https://example.org]."},
+		{"ExtRef URL Text", `See <extref href="https://example.org">https://example.org</extref>`, "See https://example.org"},
+		{"ExtRef No Text", `See <extptr xlink:href="https://example.org/a.jpg"/>`, "See [https://example.org/a.jpg]"},
+		{"ExtRef No URL", `See <extref>the website</extref>`, "See the website"},
+		{"Extent", `<extent unit="boxes">2</extent>`, "2 boxes"},
+		{"List", `Contents:<list><head>Formats</head><item>Letters</item><item>Photographs,<lb/>mostly color</item></list>End`,
+			"Contents:\nFormats\n- Letters\n- Photographs,\n  mostly color\nEnd"},
+		{"Ordered List", `<list type="ordered"><item>First</item><item>Second</item></list>`, "1. First\n2. Second"},
+		{"Nested List", `<list><item>Series I<list type="ordered"><item>Subseries 1</item><item>Subseries 2</item></list></item><item>Series II</item></list>`,
+			"- Series I\n  1. Subseries 1\n  2. Subseries 2\n- Series II"},
+		{"Definition List", `<list type="deflist"><defitem><label>ALS</label><item>Autograph letter signed</item></defitem><defitem><label>TLS</label></defitem></list>`,
+			"ALS: Autograph letter signed\nTLS"},
+		{"ChronList", `<chronlist><head>Chronology</head><chronitem><date>1920</date><event>Born</event></chronitem><chronitem><date>1945</date><eventgrp><event>Married</event><event>Moved to <geogname>Paris</geogname></event></eventgrp></chronitem></chronlist>`,
+			"Chronology\n1920: Born\n1945: Married; Moved to Paris"},
+		{"Comments", "a <!-- comment --> b", "a b"},
+	}
+
+	for _, tc := range testCases {
+		t.Run("InlineContent PlainText "+tc.name, func(t *testing.T) {
+			content, err := ParseInlineContent(tc.value)
+			failOnError(t, err, "Unexpected error parsing inline content")
+			assertEqual(t, tc.want, content.PlainText(), "PlainText()")
+		})
+	}
+}
+
+func TestGeneratedPlainText(t *testing.T) {
+	t.Run("Generated PlainText()", func(t *testing.T) {
+		p := P{Value: `See <title render="italic">Series I</title>,<lb/>and <extref href="https://example.org">the website</extref>.`}
+		assertEqual(t, "See Series I,\nand the website [https://example.org].", p.PlainText(), "P.PlainText()")
+
+		unitTitle := UnitTitle{Value: "\n  Letters from <persname>Jane Doe</persname>\n"}
+		assertEqual(t, "Letters from Jane Doe", unitTitle.PlainText(), "UnitTitle.PlainText()")
+
+		// values that are not well-formed fall back to removing the tags
+		head := Head{Value: "Tom &nbsp; <emph>Jerry</emph>"}
+		assertEqual(t, "Tom   Jerry", head.PlainText(), "Head.PlainText() fallback")
+
+		// entities are only unescaped once
+		head = Head{Value: "Use &amp;lt;br&amp;gt; <emph>tags"}
+		assertEqual(t, "Use &lt;br&gt; tags", head.PlainText(), "Head.PlainText() fallback with escaped entities")
+
+		// without lists and <lb/>, the plain text is the text with the tags removed
+		sut := getOmegaEAD(t)
+		sut.WalkComponents(func(c *C, info *WalkInfo) error {
+			if c.DID.UnitTitle != nil && !strings.Contains(c.DID.UnitTitle.Value, "<lb") {
+				want := cleanupWhitespace(tagRegexp.ReplaceAllString(c.DID.UnitTitle.Value, ""))
+				assertEqual(t, want, c.DID.UnitTitle.PlainText(), "Component UnitTitle.PlainText()")
+			}
+			return nil
+		})
+	})
+}
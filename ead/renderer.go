@@ -0,0 +1,441 @@
+package ead
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LinkTargetPolicy determines the target attribute of rendered links
+type LinkTargetPolicy string
+
+const (
+	// LinkTargetShow sets the target to the @show value, e.g., target="new"
+	LinkTargetShow LinkTargetPolicy = "show"
+	// LinkTargetNone omits the target
+	LinkTargetNone LinkTargetPolicy = "none"
+	// LinkTargetBlank opens links in a new browsing context, i.e.,
+	// target="_blank" rel="noopener noreferrer"
+	LinkTargetBlank LinkTargetPolicy = "blank"
+)
+
+// ElementRendering configures how an EAD element is rendered as HTML
+type ElementRendering struct {
+	// Tag is the HTML tag, e.g., "span".  If Tag is empty, only the content
+	// of the element is rendered.
+	Tag string `yaml:"tag" json:"tag,omitempty"`
+
+	// RenderTags maps @render values to tags that replace Tag, e.g.,
+	// "bold": "strong"
+	RenderTags map[string]string `yaml:"render_tags" json:"render_tags,omitempty"`
+
+	// Class is the class name without the ClassPrefix.  "{name}" is
+	// replaced with the element name.
+	Class string `yaml:"class" json:"class,omitempty"`
+
+	// RenderClass is the additional class name, without the ClassPrefix,
+	// of elements with a @render.  "{render}" is replaced with the @render
+	// value, e.g., "emph-{render}".
+	RenderClass string `yaml:"render_class" json:"render_class,omitempty"`
+
+	// Attr maps EAD attribute local names to HTML attribute names, e.g.,
+	// "normal": "data-normal"
+	Attr map[string]string `yaml:"attr" json:"attr,omitempty"`
+
+	// Link renders the @href as the href, if IsSafeURL(), and the target
+	// per the Renderer LinkTarget
+	Link bool `yaml:"link" json:"link,omitempty"`
+
+	// Void renders the element as an HTML void element, e.g., <br>, without
+	// content or end tag
+	Void bool `yaml:"void" json:"void,omitempty"`
+}
+
+// Renderer configures the HTML rendering of inline content, e.g., to render
+// <emph render="italic"> as <em> instead of <span class="ead-emph ead-emph-italic">.
+// Renderers can be written in YAML or JSON, e.g.,
+//
+//	class_prefix: "ead-"
+//	link_target: blank
+//	elements:
+//	  emph:
+//	    tag: em
+//	    render_tags:
+//	      bold: strong
+//	  title:
+//	    tag: cite
+//
+// Any setting that is not present falls back to the value from NewRenderer().
+// Elements that are present replace the default rendering of that element.
+type Renderer struct {
+	// ClassPrefix is prepended to each class name, e.g., "ead-"
+	ClassPrefix string `yaml:"class_prefix" json:"class_prefix"`
+
+	// Elements maps element local names, e.g., "emph", to their rendering
+	Elements map[string]ElementRendering `yaml:"elements" json:"elements,omitempty"`
+
+	// Default is the rendering of elements that are not in Elements
+	Default ElementRendering `yaml:"default" json:"default"`
+
+	LinkTarget LinkTargetPolicy `yaml:"link_target" json:"link_target"`
+}
+
+// NewRenderer returns a Renderer configured for the iJSON "value" rendering,
+// e.g., <emph render="italic"> as <span class="ead-emph ead-emph-italic">,
+// <extref> as <a class="ead-extref">, and <lb/> as <br>
+func NewRenderer() *Renderer {
+	return &Renderer{
+		ClassPrefix: "ead-",
+		Elements: map[string]ElementRendering{
+			"emph": {Tag: "span", Class: "emph", RenderClass: "emph-{render}"},
+			// NOTE: the use of emph-{render} below is INTENTIONAL
+			// This eliminates the need for per-element selectors for the render attribute
+			"title":  {Tag: "span", Class: "title", RenderClass: "emph-{render}"},
+			"extref": {Tag: "a", Class: "extref", Link: true},
+			"ref":    {Tag: "a", Class: "ref", Link: true},
+			"lb":     {Tag: "br", Void: true},
+		},
+		Default:    ElementRendering{Tag: "span", Class: "{name}"},
+		LinkTarget: LinkTargetShow,
+	}
+}
+
+// defaultRenderer is the Renderer used by InlineContent.HTML(), and by the
+// iJSON "value" fields of an EAD without a Renderer, see EAD.SetRenderer().
+// It is never modified.
+var defaultRenderer = NewRenderer()
+
+// rendering holds the Renderer of a type that renders its content as HTML,
+// e.g., for its iJSON "value" field
+type rendering struct {
+	renderer *Renderer
+}
+
+// htmlRenderer returns the Renderer set by EAD.SetRenderer(), or the default
+// Renderer
+func (rd *rendering) htmlRenderer() *Renderer {
+	if rd.renderer == nil {
+		return defaultRenderer
+	}
+	return rd.renderer
+}
+
+func (rd *rendering) setRenderer(r *Renderer) {
+	rd.renderer = r
+}
+
+// SetRenderer sets the Renderer of the iJSON "value" fields, the table HTML,
+// and the date summary and Hugo titles of the EAD.  A nil Renderer restores
+// the default rendering, NewRenderer().
+//
+// The Renderer is set on the elements of the EAD, so EADs can be marshaled
+// with different Renderers concurrently.  Elements added to the EAD later,
+// e.g., by InitPresentationComponents(), use the default rendering until
+// SetRenderer is called again.
+func (e *EAD) SetRenderer(r *Renderer) {
+	walkDecodedValues(reflect.ValueOf(e), func(v reflect.Value) bool {
+		if setter, ok := v.Addr().Interface().(interface{ setRenderer(*Renderer) }); ok {
+			setter.setRenderer(r)
+		}
+		return true
+	})
+}
+
+// ReadRenderer reads a YAML or JSON Renderer.
+// Unknown settings are rejected so that typos do not silently fall back to defaults.
+func ReadRenderer(r io.Reader) (*Renderer, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	renderer := NewRenderer()
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	// YAML is a superset of JSON, so the YAML decoder handles both formats
+	err = decoder.Decode(renderer)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("malformed renderer: %w", err)
+	}
+
+	err = renderer.Check()
+	if err != nil {
+		return nil, fmt.Errorf("invalid renderer: %w", err)
+	}
+
+	return renderer, nil
+}
+
+// ReadRendererFromFile reads a YAML or JSON Renderer from a file
+func ReadRendererFromFile(filepath string) (*Renderer, error) {
+	f, err := os.Open(filepath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	renderer, err := ReadRenderer(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath, err)
+	}
+
+	return renderer, nil
+}
+
+var htmlNameRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9-]*$`)
+
+// unsafeHTMLTags are the tags whose content is executed or applied by the
+// browser instead of displayed
+var unsafeHTMLTags = map[string]bool{
+	"script": true,
+	"style":  true,
+}
+
+// Check returns an error if the link target policy is unknown, if a tag or
+// attribute name is not a valid HTML name, if a tag is "script" or "style",
+// or if an attribute name is an event handler, e.g., "onclick".  The tag and
+// attribute names are rendered as-is, so they must be checked before use.
+func (r *Renderer) Check() error {
+	switch r.LinkTarget {
+	case LinkTargetShow, LinkTargetNone, LinkTargetBlank:
+	default:
+		return fmt.Errorf("unknown link_target %q", r.LinkTarget)
+	}
+
+	err := r.Default.check()
+	if err != nil {
+		return fmt.Errorf("default: %w", err)
+	}
+
+	// sort for deterministic error messages
+	var names []string
+	for name := range r.Elements {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		rendering := r.Elements[name]
+		err := rendering.check()
+		if err != nil {
+			return fmt.Errorf("elements: %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (er *ElementRendering) check() error {
+	tags := []string{er.Tag}
+	for _, tag := range er.RenderTags {
+		tags = append(tags, tag)
+	}
+	var attrNames []string
+	for _, attrName := range er.Attr {
+		attrNames = append(attrNames, attrName)
+	}
+	// sort for deterministic error messages
+	sort.Strings(tags)
+	sort.Strings(attrNames)
+
+	for _, name := range append(tags, attrNames...) {
+		if name != "" && !htmlNameRegexp.MatchString(name) {
+			return fmt.Errorf("%q is not a valid HTML name", name)
+		}
+	}
+
+	for _, tag := range tags {
+		if unsafeHTMLTags[strings.ToLower(tag)] {
+			return fmt.Errorf("%q is not an allowed HTML tag", tag)
+		}
+	}
+
+	for _, attrName := range attrNames {
+		if strings.HasPrefix(strings.ToLower(attrName), "on") {
+			return fmt.Errorf("%q is an event handler attribute, which is not allowed", attrName)
+		}
+	}
+
+	return nil
+}
+
+// HTML renders the inline content as HTML per the Renderer configuration.
+// Character data and attribute values are escaped, and the href of links is
+// omitted unless IsSafeURL() returns true.
+func (r *Renderer) HTML(ic InlineContent) string {
+	return r.renderHTML(ic, true)
+}
+
+// HTMLNoLBConversion renders the inline content as HTML, but renders <lb/>
+// with the Default rendering instead of its Elements rendering
+func (r *Renderer) HTMLNoLBConversion(ic InlineContent) string {
+	return r.renderHTML(ic, false)
+}
+
+// convertTextWithTags parses the inline content of the text, and renders it
+// as HTML
+func (r *Renderer) convertTextWithTags(text string) ([]byte, error) {
+	content, err := ParseInlineContent(text)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(r.HTML(content)), nil
+}
+
+// convertTextWithTagsNoLBConversion is convertTextWithTags with the
+// HTMLNoLBConversion() rendering
+func (r *Renderer) convertTextWithTagsNoLBConversion(text string) ([]byte, error) {
+	content, err := ParseInlineContent(text)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(r.HTMLNoLBConversion(content)), nil
+}
+
+func (r *Renderer) renderHTML(ic InlineContent, convertLBTags bool) string {
+	var sb strings.Builder
+	r.writeHTML(&sb, ic, convertLBTags)
+	return collapseWhitespace(sb.String())
+}
+
+func (r *Renderer) writeHTML(sb *strings.Builder, ic InlineContent, convertLBTags bool) {
+	for _, node := range ic {
+		switch node.Type {
+		case InlineTextNode:
+			sb.WriteString(escapeHTMLText(strings.ReplaceAll(node.Text, "\n", " ")))
+		case InlineElementNode:
+			r.writeElementHTML(sb, node, convertLBTags)
+		}
+	}
+}
+
+func (r *Renderer) elementRendering(name string, convertLBTags bool) ElementRendering {
+	if name == "lb" && !convertLBTags {
+		return r.Default
+	}
+	if rendering, ok := r.Elements[name]; ok {
+		return rendering
+	}
+	return r.Default
+}
+
+// rendersElement returns true if the element has its own rendering in
+// Elements, e.g., <emph> or <lb/>, instead of the Default rendering of the
+// unknown elements
+func (r *Renderer) rendersElement(name string) bool {
+	_, ok := r.Elements[name]
+	return ok
+}
+
+// rendersAttribute returns true if the attribute of the element is part of
+// the rendered HTML, e.g., the @render of an <emph> as a class name
+func (r *Renderer) rendersAttribute(name string, attrName string) bool {
+	rendering := r.elementRendering(name, true)
+	switch {
+	case attrName == "render":
+		return rendering.RenderClass != "" || len(rendering.RenderTags) > 0
+	case attrName == "href":
+		return rendering.Link
+	case attrName == "show":
+		return rendering.Link && r.LinkTarget == LinkTargetShow
+	case name == "extent" && attrName == "unit":
+		return true
+	}
+	_, ok := rendering.Attr[attrName]
+	return ok
+}
+
+func (r *Renderer) writeElementHTML(sb *strings.Builder, node *InlineNode, convertLBTags bool) {
+	name := node.LocalName()
+	rendering := r.elementRendering(name, convertLBTags)
+	render := node.Attribute("render")
+
+	tag := rendering.Tag
+	if renderTag, ok := rendering.RenderTags[render]; ok && render != "" {
+		tag = renderTag
+	}
+
+	if tag != "" {
+		sb.WriteString("<" + tag)
+		r.writeAttributesHTML(sb, node, rendering, name, render)
+		sb.WriteString(">")
+		if rendering.Void {
+			return
+		}
+	}
+
+	r.writeHTML(sb, node.Children, convertLBTags)
+
+	// append the "unit" attribute value to the extent
+	if name == "extent" {
+		if unit := node.Attribute("unit"); unit != "" {
+			sb.WriteString(" " + escapeHTMLText(unit))
+		}
+	}
+
+	if tag != "" {
+		sb.WriteString("</" + tag + ">")
+	}
+}
+
+func (r *Renderer) writeAttributesHTML(sb *strings.Builder, node *InlineNode, rendering ElementRendering, name string, render string) {
+	var classes []string
+	if rendering.Class != "" {
+		classes = append(classes, r.ClassPrefix+strings.ReplaceAll(rendering.Class, "{name}", name))
+	}
+	if rendering.RenderClass != "" && render != "" {
+		classes = append(classes, r.ClassPrefix+strings.ReplaceAll(rendering.RenderClass, "{render}", render))
+	}
+	if len(classes) > 0 {
+		writeHTMLAttribute(sb, "class", strings.Join(classes, " "))
+	}
+
+	if rendering.Link {
+		if href := node.Attribute("href"); IsSafeURL(href) {
+			writeHTMLAttribute(sb, "href", href)
+		}
+	}
+
+	// sort for deterministic attribute order
+	var attrNames []string
+	for attrName := range rendering.Attr {
+		attrNames = append(attrNames, attrName)
+	}
+	sort.Strings(attrNames)
+
+	for _, attrName := range attrNames {
+		value := node.Attribute(attrName)
+		if value == "" {
+			continue
+		}
+		htmlName := rendering.Attr[attrName]
+		if (htmlName == "href" || htmlName == "src") && !IsSafeURL(value) {
+			continue
+		}
+		writeHTMLAttribute(sb, htmlName, value)
+	}
+
+	if rendering.Link {
+		switch r.LinkTarget {
+		case LinkTargetShow:
+			writeHTMLAttribute(sb, "target", node.Attribute("show"))
+		case LinkTargetBlank:
+			writeHTMLAttribute(sb, "target", "_blank")
+			writeHTMLAttribute(sb, "rel", "noopener noreferrer")
+		}
+	}
+}
+
+func writeHTMLAttribute(sb *strings.Builder, name string, value string) {
+	sb.WriteString(fmt.Sprintf(` %s="%s"`, name, html.EscapeString(value)))
+}
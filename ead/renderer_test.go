@@ -0,0 +1,142 @@
+package ead
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var rendererTestFixturePath string = filepath.Join(testFixturePath, "renderer")
+
+func TestRenderer(t *testing.T) {
+	content, err := ParseInlineContent(inlineTestValue)
+	failOnError(t, err, "Unexpected error parsing inline content")
+
+	t.Run("NewRenderer", func(t *testing.T) {
+		assertEqual(t, content.HTML(), NewRenderer().HTML(content), "NewRenderer().HTML()")
+		assertEqual(t, content.HTMLNoLBConversion(), NewRenderer().HTMLNoLBConversion(content), "NewRenderer().HTMLNoLBConversion()")
+	})
+
+	t.Run("ReadRendererFromFile", func(t *testing.T) {
+		renderer, err := ReadRendererFromFile(filepath.Join(rendererTestFixturePath, "semantic.yaml"))
+		failOnError(t, err, "Unexpected error reading renderer")
+
+		want := `Letters from <span class="fa-persname">Jane <em>Doe</em></span>,<br> regarding <cite class="fa-doublequote">Tom &amp; Jerry</cite> and <a href="https://example.org/?a=1&amp;b=2" target="_blank" rel="noopener noreferrer">the website</a> <span class="fa-extent">2.5 linear feet</span> <time datetime="1920">1920</time>`
+		assertEqual(t, want, renderer.HTML(content), "HTML()")
+
+		bold, err := ParseInlineContent(`<emph render="bold">bold</emph> <ref href="#a">ref</ref>`)
+		failOnError(t, err, "Unexpected error parsing inline content")
+		// <ref> is not in the elements of the file, so the default <ref> rendering is used
+		assertEqual(t, `<strong>bold</strong> <a class="fa-ref" href="#a" target="_blank" rel="noopener noreferrer">ref</a>`, renderer.HTML(bold), "HTML() RenderTags")
+	})
+
+	t.Run("ReadRenderer JSON", func(t *testing.T) {
+		renderer, err := ReadRenderer(strings.NewReader(`{"link_target": "none", "default": {"tag": ""}}`))
+		failOnError(t, err, "Unexpected error reading renderer")
+
+		got := renderer.HTML(content)
+		assertContains(t, got, `Letters from Jane <span class="ead-emph ead-emph-italic">Doe</span>`, "HTML() empty Tag")
+		assertContains(t, got, `<a class="ead-extref" href="https://example.org/?a=1&amp;b=2">the website</a>`, "HTML() LinkTargetNone")
+	})
+
+	t.Run("Renderer Attribute Escaping", func(t *testing.T) {
+		renderer := NewRenderer()
+		renderer.Elements["extref"] = ElementRendering{Tag: "a", Attr: map[string]string{"href": "href", "title": "title"}}
+
+		sut, err := ParseInlineContent(`<extref href="javascript:alert(1)" title='"&gt;&lt;script&gt;'>x</extref>`)
+		failOnError(t, err, "Unexpected error parsing inline content")
+		assertEqual(t, `<a title="&#34;&gt;&lt;script&gt;">x</a>`, renderer.HTML(sut), "HTML()")
+	})
+
+	t.Run("ReadRenderer Errors", func(t *testing.T) {
+		testCases := []struct {
+			value string
+			want  string
+		}{
+			{`link_target: parent`, `unknown link_target "parent"`},
+			{"elements:\n  emph:\n    tag: 'em onclick=\"alert(1)\"'", `elements: emph: "em onclick=\"alert(1)\"" is not a valid HTML name`},
+			{"default:\n  attr:\n    normal: 'data normal'", `default: "data normal" is not a valid HTML name`},
+			{"elements:\n  emph:\n    tag: script", `elements: emph: "script" is not an allowed HTML tag`},
+			{"elements:\n  emph:\n    render_tags:\n      bold: STYLE", `elements: emph: "STYLE" is not an allowed HTML tag`},
+			{"default:\n  attr:\n    audience: onMouseOver", `default: "onMouseOver" is an event handler attribute, which is not allowed`},
+		}
+		for _, tc := range testCases {
+			_, err := ReadRenderer(strings.NewReader(tc.value))
+			if err == nil {
+				t.Fatalf("Expected an error reading %q", tc.value)
+			}
+			assertContains(t, err.Error(), tc.want, "ReadRenderer() error")
+		}
+
+		_, err := ReadRendererFromFile(filepath.Join(rendererTestFixturePath, "unknown-setting.yaml"))
+		if err == nil {
+			t.Fatalf("Expected an error for an unknown setting")
+		}
+		assertContains(t, err.Error(), "field class_prefx not found", "ReadRendererFromFile() error")
+	})
+}
+
+func TestEADSetRenderer(t *testing.T) {
+	EADXML := `<ead><archdesc level="collection"><did><unittitle>Letters <emph render="bold">1920</emph></unittitle></did>` +
+		`<scopecontent><p>Photographs in <emph render="bold">color</emph></p></scopecontent>` +
+		`<dsc><c id="c1"><did><unittitle><emph render="bold">Maps</emph></unittitle></did></c></dsc></archdesc></ead>`
+
+	decode := func(t *testing.T) *EAD {
+		var e EAD
+		err := xml.Unmarshal([]byte(EADXML), &e)
+		failOnError(t, err, "Unexpected error unmarshaling EAD")
+		return &e
+	}
+	marshal := func(t *testing.T, e *EAD) string {
+		jsonData, err := json.Marshal(e)
+		failOnError(t, err, "Unexpected error marshaling EAD")
+		return string(jsonData)
+	}
+
+	renderer, err := ReadRendererFromFile(filepath.Join(rendererTestFixturePath, "semantic.yaml"))
+	failOnError(t, err, "Unexpected error reading renderer")
+
+	t.Run("EAD SetRenderer", func(t *testing.T) {
+		sut := decode(t)
+		other := decode(t)
+		sut.SetRenderer(renderer)
+
+		got := marshal(t, sut)
+		for _, want := range []string{`Letters \u003cstrong\u003e1920`, `Photographs in \u003cstrong\u003ecolor`, `\u003cstrong\u003eMaps`} {
+			assertContains(t, got, want, "SetRenderer() iJSON")
+		}
+		if strings.Contains(got, "ead-emph") {
+			t.Errorf("Expected no default rendering in the iJSON, got %s", got)
+		}
+
+		// the Renderer is set on the EAD only
+		assertContains(t, marshal(t, other), `\u003cspan class=\"ead-emph ead-emph-bold\"\u003eMaps`, "Other EAD iJSON")
+
+		sut.SetRenderer(nil)
+		assertEqual(t, marshal(t, other), marshal(t, sut), "SetRenderer(nil) iJSON")
+	})
+
+	t.Run("EAD SetRenderer Concurrently", func(t *testing.T) {
+		sut := decode(t)
+		sut.SetRenderer(renderer)
+		want := marshal(t, sut)
+		other := decode(t)
+		wantOther := marshal(t, other)
+
+		results := make(chan [2]string)
+		for i := 0; i < 4; i++ {
+			go func() {
+				jsonData, _ := json.Marshal(sut)
+				otherJSONData, _ := json.Marshal(other)
+				results <- [2]string{string(jsonData), string(otherJSONData)}
+			}()
+		}
+		for i := 0; i < 4; i++ {
+			got := <-results
+			assertEqual(t, want, got[0], "Concurrent iJSON with Renderer")
+			assertEqual(t, wantOther, got[1], "Concurrent iJSON without Renderer")
+		}
+	})
+}
@@ -0,0 +1,236 @@
+package ead
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+)
+
+// StreamHandler holds the functions called by StreamEAD as the parts of the
+// EAD are decoded.  Any of them may be nil.
+//
+// If a function returns SkipAll, streaming stops and StreamEAD returns nil.
+// If it returns any other non-nil error, streaming stops and StreamEAD
+// returns that error.
+type StreamHandler struct {
+	// EADHeader is called once the <eadheader> has been decoded
+	EADHeader func(e *EAD) error
+
+	// ArchDesc is called once before the first top-level component, or at
+	// the end of the <archdesc> if there are no components.  The <archdesc>
+	// elements that follow the <dsc> have not been decoded yet.
+	ArchDesc func(e *EAD) error
+
+	// C is called for each top-level component, with its subcomponents.
+	// index is the index of the component among the top-level components.
+	// The component is not retained by StreamEAD.
+	C func(e *EAD, c *C, index int) error
+}
+
+// StreamEAD decodes the EAD XML read from r incrementally, calling the
+// handler's functions for the header, the archdesc summary, and each
+// top-level component.
+//
+// Only one top-level component is held in memory at a time, so very large
+// finding aids can be processed with memory bounded by the size of the
+// largest top-level component rather than the size of the file.
+//
+// StreamEAD returns the EAD without its top-level components, i.e., with
+// ArchDesc.DSC.C empty.  The returned EAD includes the <archdesc> elements
+// that follow the <dsc>.
+func StreamEAD(r io.Reader, handler StreamHandler) (*EAD, error) {
+	s := &eadStream{
+		decoder: xml.NewDecoder(r),
+		handler: handler,
+		ead:     &EAD{},
+	}
+
+	err := s.decode()
+	if errors.Is(err, SkipAll) {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ead, nil
+}
+
+type eadStream struct {
+	decoder         *xml.Decoder
+	handler         StreamHandler
+	ead             *EAD
+	archDescEmitted bool
+	cIndex          int
+}
+
+func (s *eadStream) decode() error {
+	root, err := s.nextStartElement()
+	if err != nil {
+		return err
+	}
+
+	for {
+		token, err := s.decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "eadheader":
+				err = s.decodeEADHeader(t)
+			case "archdesc":
+				err = s.decodeArchDesc(t)
+			default:
+				err = s.decoder.Skip()
+			}
+			if err != nil {
+				return err
+			}
+		case xml.EndElement:
+			if t.Name == root.Name {
+				return nil
+			}
+		}
+	}
+}
+
+func (s *eadStream) nextStartElement() (xml.StartElement, error) {
+	for {
+		token, err := s.decoder.Token()
+		if err != nil {
+			return xml.StartElement{}, err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start, nil
+		}
+	}
+}
+
+func (s *eadStream) decodeEADHeader(start xml.StartElement) error {
+	err := s.decoder.DecodeElement(&s.ead.EADHeader, &start)
+	if err != nil {
+		return err
+	}
+	if err := checkSupportedElements(&s.ead.EADHeader); err != nil {
+		return err
+	}
+
+	if s.handler.EADHeader != nil {
+		return s.handler.EADHeader(s.ead)
+	}
+	return nil
+}
+
+func (s *eadStream) decodeArchDesc(start xml.StartElement) error {
+	if s.ead.ArchDesc == nil {
+		s.ead.ArchDesc = &ArchDesc{}
+	}
+
+	err := s.decoder.DecodeElement(&streamArchDesc{
+		ArchDesc: s.ead.ArchDesc,
+		DSC:      &streamDSC{stream: s},
+	}, &start)
+	if err != nil {
+		return err
+	}
+	// the elements that follow the <dsc> are only checked here
+	if err := checkSupportedElements(s.ead.ArchDesc); err != nil {
+		return err
+	}
+
+	return s.emitArchDesc()
+}
+
+func (s *eadStream) emitArchDesc() error {
+	if s.archDescEmitted {
+		return nil
+	}
+	s.archDescEmitted = true
+
+	if err := checkSupportedElements(s.ead.ArchDesc); err != nil {
+		return err
+	}
+	if s.handler.ArchDesc != nil {
+		return s.handler.ArchDesc(s.ead)
+	}
+	return nil
+}
+
+// streamArchDesc decodes the <archdesc> into the embedded ArchDesc, except
+// for the <dsc>, which is decoded by streamDSC.  The DSC field shadows the
+// ArchDesc.DSC field.
+type streamArchDesc struct {
+	*ArchDesc
+	DSC *streamDSC `xml:"dsc"`
+}
+
+type streamDSC struct {
+	stream *eadStream
+}
+
+// UnmarshalXML passes each top-level <c> or <c01> to the StreamHandler instead of
+// adding it to the DSC
+func (sd *streamDSC) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	s := sd.stream
+	if s.ead.ArchDesc.DSC == nil {
+		s.ead.ArchDesc.DSC = &DSC{}
+	}
+	dsc := s.ead.ArchDesc.DSC
+
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch {
+			case IsComponentName(t.Name.Local):
+				err = s.decodeC(t)
+			case t.Name.Local == "p":
+				p := &P{}
+				err = d.DecodeElement(p, &t)
+				dsc.P = append(dsc.P, p)
+			case t.Name.Local == "table":
+				table := &Table{}
+				err = d.DecodeElement(table, &t)
+				dsc.Table = append(dsc.Table, table)
+			default:
+				err = d.Skip()
+			}
+			if err != nil {
+				return err
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+func (s *eadStream) decodeC(start xml.StartElement) error {
+	err := s.emitArchDesc()
+	if err != nil {
+		return err
+	}
+
+	c := &C{}
+	err = s.decoder.DecodeElement(c, &start)
+	if err != nil {
+		return err
+	}
+	if err := checkSupportedElements(c); err != nil {
+		return err
+	}
+
+	index := s.cIndex
+	s.cIndex++
+
+	if s.handler.C != nil {
+		return s.handler.C(s.ead, c, index)
+	}
+	return nil
+}
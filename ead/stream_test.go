@@ -0,0 +1,241 @@
+package ead
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+var streamTestFixturePaths = []string{
+	filepath.Join(omegaTestFixturePath, "Omega-EAD.xml"),
+	filepath.Join(testFixturePath, "akkasah", "ad_mc_030_ref184.xml"),
+	filepath.Join(testFixturePath, "cbh", "arc_212_plymouth_beecher.xml"),
+	filepath.Join(testFixturePath, "fales", "mss_460.xml"),
+	filepath.Join(testFixturePath, "nyhs", "nyhs_foundling.xml"),
+	filepath.Join(presentationComponentPath, "pc-no-components.xml"),
+	filepath.Join(testFixturePath, "block-elements", "block-elements.xml"),
+}
+
+func TestStreamEAD(t *testing.T) {
+	for _, eadPath := range streamTestFixturePaths {
+		t.Run(fmt.Sprintf("StreamEAD %s", filepath.Base(eadPath)), func(t *testing.T) {
+			want := getTestEAD(t, eadPath)
+
+			f, err := os.Open(eadPath)
+			failOnError(t, err, "Unexpected error opening EAD")
+			defer f.Close()
+
+			var events []string
+			var cs []*C
+			got, err := StreamEAD(f, StreamHandler{
+				EADHeader: func(e *EAD) error {
+					events = append(events, "eadheader")
+					assertEqual(t, want.EADID(), e.EADID(), "EADID")
+					return nil
+				},
+				ArchDesc: func(e *EAD) error {
+					events = append(events, "archdesc")
+					assertEqual(t, string(want.ArchDesc.Level), string(e.ArchDesc.Level), "ArchDesc Level")
+					return nil
+				},
+				C: func(e *EAD, c *C, index int) error {
+					assertEqual(t, fmt.Sprint(len(cs)), fmt.Sprint(index), "Component index")
+					events = append(events, "c")
+					cs = append(cs, c)
+					return nil
+				},
+			})
+			failOnError(t, err, "Unexpected error streaming EAD")
+
+			wantEvents := []string{"eadheader", "archdesc"}
+			if want.ArchDesc.DSC != nil {
+				for range want.ArchDesc.DSC.C {
+					wantEvents = append(wantEvents, "c")
+				}
+			}
+			assertEqual(t, strings.Join(wantEvents, " "), strings.Join(events, " "), "Handler calls")
+
+			if got.ArchDesc.DSC != nil {
+				if len(got.ArchDesc.DSC.C) != 0 {
+					t.Errorf("Expected streamed components not to be retained")
+				}
+				got.ArchDesc.DSC.C = cs
+			}
+
+			wantJSON, err := json.Marshal(want)
+			failOnError(t, err, "Unexpected error marshaling JSON")
+			gotJSON, err := json.Marshal(got)
+			failOnError(t, err, "Unexpected error marshaling JSON")
+			if !bytes.Equal(wantJSON, gotJSON) {
+				t.Errorf("Streamed EAD does not match unmarshaled EAD")
+			}
+		})
+	}
+}
+
+func TestStreamEADStop(t *testing.T) {
+	t.Run("StreamEAD SkipAll", func(t *testing.T) {
+		var count int
+		got, err := StreamEAD(strings.NewReader(syntheticEAD(5, 2)), StreamHandler{
+			C: func(e *EAD, c *C, index int) error {
+				count++
+				if index == 1 {
+					return SkipAll
+				}
+				return nil
+			},
+		})
+		failOnError(t, err, "Unexpected error streaming EAD")
+		assertEqual(t, "2", fmt.Sprint(count), "Components streamed before SkipAll")
+		assertEqual(t, "synthetic", got.EADID(), "EADID")
+	})
+
+	t.Run("StreamEAD Handler Error", func(t *testing.T) {
+		handlerErr := errors.New("handler error")
+		_, err := StreamEAD(strings.NewReader(syntheticEAD(5, 2)), StreamHandler{
+			ArchDesc: func(e *EAD) error {
+				return handlerErr
+			},
+		})
+		if !errors.Is(err, handlerErr) {
+			t.Errorf("Expected handler error, got %v", err)
+		}
+	})
+
+	t.Run("StreamEAD Invalid XML", func(t *testing.T) {
+		_, err := StreamEAD(strings.NewReader("<ead><eadheader></ead>"), StreamHandler{})
+		if err == nil {
+			t.Errorf("Expected an error for invalid XML")
+		}
+
+		_, err = StreamEAD(strings.NewReader(""), StreamHandler{})
+		if err != io.EOF {
+			t.Errorf("Expected io.EOF for empty input, got %v", err)
+		}
+	})
+}
+
+// syntheticEAD returns an EAD with numCs top-level components, each with
+// numSubCs subcomponents
+func syntheticEAD(numCs int, numSubCs int) string {
+	var sb strings.Builder
+	writeSyntheticEAD(&sb, numCs, numSubCs)
+	return sb.String()
+}
+
+func writeSyntheticEAD(w io.Writer, numCs int, numSubCs int) error {
+	_, err := io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?>
+<ead xmlns="urn:isbn:1-931666-22-9" xmlns:xlink="http://www.w3.org/1999/xlink">
+<eadheader><eadid>synthetic</eadid><filedesc><titlestmt><titleproper>Synthetic Papers</titleproper></titlestmt></filedesc></eadheader>
+<archdesc level="collection"><did><unittitle>Synthetic Papers</unittitle><unitid>synthetic</unitid><unitdate normal="1900/1999">1900-1999</unitdate></did>
+<scopecontent><head>Scope and Contents</head><p>A synthetic finding aid.</p></scopecontent>
+<dsc>
+`)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < numCs; i++ {
+		_, err = fmt.Fprintf(w, `<c id="series_%d" level="series"><did><unittitle>Series %d</unittitle></did>
+`, i, i)
+		if err != nil {
+			return err
+		}
+		for j := 0; j < numSubCs; j++ {
+			_, err = fmt.Fprintf(w, `<c id="file_%d_%d" level="file"><did><unittitle>Letters from <persname>Correspondent %d</persname></unittitle><unitdate normal="1920/1925">1920-1925</unitdate><container id="box_%d_%d" type="Box" label="Mixed Materials [3114204221%04d]">%d</container><container parent="box_%d_%d" type="Folder">%d</container></did><scopecontent><p>Correspondence regarding <emph render="italic">synthetic</emph> matters.</p></scopecontent></c>
+`, i, j, j, i, j, j, i, i, j, j)
+			if err != nil {
+				return err
+			}
+		}
+		_, err = io.WriteString(w, "</c>\n")
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = io.WriteString(w, "</dsc>\n</archdesc>\n</ead>\n")
+	return err
+}
+
+// syntheticEADReader streams a synthetic EAD without holding it in memory
+func syntheticEADReader(numCs int, numSubCs int) io.Reader {
+	r, w := io.Pipe()
+	go func() {
+		w.CloseWithError(writeSyntheticEAD(w, numCs, numSubCs))
+	}()
+	return r
+}
+
+// about 50 MB of EAD XML
+const benchmarkNumCs = 500
+const benchmarkNumSubCs = 250
+
+func reportPeakHeap(b *testing.B, peak uint64) {
+	b.ReportMetric(float64(peak)/1_000_000, "peak-heap-MB")
+}
+
+func updatePeakHeap(peak *uint64) {
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+	if memStats.HeapInuse > *peak {
+		*peak = memStats.HeapInuse
+	}
+}
+
+func BenchmarkStreamEAD(b *testing.B) {
+	b.ReportAllocs()
+	var peak uint64
+	for i := 0; i < b.N; i++ {
+		runtime.GC()
+		var count int
+		_, err := StreamEAD(syntheticEADReader(benchmarkNumCs, benchmarkNumSubCs), StreamHandler{
+			C: func(e *EAD, c *C, index int) error {
+				count += len(c.C)
+				if index%50 == 0 {
+					updatePeakHeap(&peak)
+				}
+				return nil
+			},
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+		if count != benchmarkNumCs*benchmarkNumSubCs {
+			b.Fatalf("Expected %d subcomponents, got %d", benchmarkNumCs*benchmarkNumSubCs, count)
+		}
+	}
+	reportPeakHeap(b, peak)
+}
+
+func BenchmarkUnmarshalEAD(b *testing.B) {
+	b.ReportAllocs()
+	var peak uint64
+	for i := 0; i < b.N; i++ {
+		runtime.GC()
+		EADXML, err := io.ReadAll(syntheticEADReader(benchmarkNumCs, benchmarkNumSubCs))
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		var sut EAD
+		err = xml.Unmarshal(EADXML, &sut)
+		if err != nil {
+			b.Fatal(err)
+		}
+		updatePeakHeap(&peak)
+
+		if len(sut.ArchDesc.DSC.C) != benchmarkNumCs {
+			b.Fatalf("Expected %d components, got %d", benchmarkNumCs, len(sut.ArchDesc.DSC.C))
+		}
+	}
+	reportPeakHeap(b, peak)
+}
@@ -0,0 +1,321 @@
+package ead
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MaxTableColumns is the largest number of columns of a <tgroup> that is
+// rendered.  The HTML and Markdown of a <tgroup> with a larger @cols, or
+// with an <entry> past the @cols or past MaxTableColumns if the @cols is
+// missing, is an error.
+const MaxTableColumns = 1000
+
+// HTML renders the <table> as an HTML <table> with the Renderer of the EAD,
+// see EAD.SetRenderer() and Renderer.TableHTML()
+func (table *Table) HTML() (string, error) {
+	return table.htmlRenderer().TableHTML(table)
+}
+
+// TableHTML renders the <table> as HTML: each <tgroup> is rendered as a
+// <table>, the <head> as the <caption> of the first <table>, <thead> entries
+// as <th> cells, and <tbody> entries as <td> cells.  The @morerows of an
+// <entry> is rendered as a rowspan, and the @namest/@nameend span as a
+// colspan.  Columns skipped by an <entry> @colname or @namest are rendered
+// as empty cells.
+func (r *Renderer) TableHTML(table *Table) (string, error) {
+	var sb strings.Builder
+	for i, tgroup := range table.TGroup {
+		sb.WriteString("<table")
+		writeHTMLAttribute(&sb, "class", r.ClassPrefix+"table")
+		sb.WriteString(">")
+
+		if i == 0 && table.Head != nil {
+			content, err := table.Head.Inline()
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString("<caption>" + r.HTML(content) + "</caption>")
+		}
+
+		layout, err := newTableLayout(tgroup)
+		if err != nil {
+			return "", err
+		}
+		if tgroup.THead != nil {
+			sb.WriteString("<thead>")
+			if err := r.writeTableRowsHTML(&sb, layout, tgroup.THead.Row, "th"); err != nil {
+				return "", err
+			}
+			sb.WriteString("</thead>")
+		}
+		if tgroup.TBody != nil {
+			sb.WriteString("<tbody>")
+			if err := r.writeTableRowsHTML(&sb, layout, tgroup.TBody.Row, "td"); err != nil {
+				return "", err
+			}
+			sb.WriteString("</tbody>")
+		}
+
+		sb.WriteString("</table>")
+	}
+	return sb.String(), nil
+}
+
+func (r *Renderer) writeTableRowsHTML(sb *strings.Builder, layout *tableLayout, rows []*Row, cellTag string) error {
+	// the <thead> and <tbody> rows are laid out separately
+	layout.occupied = map[int]int{}
+
+	for _, row := range rows {
+		sb.WriteString("<tr>")
+
+		column := layout.nextFreeColumn(1)
+		for _, entry := range row.Entry {
+			start, end, err := layout.entryColumns(entry, column)
+			if err != nil {
+				return err
+			}
+
+			// fill the columns skipped by the entry
+			for ; column < start; column = layout.nextFreeColumn(column + 1) {
+				sb.WriteString("<" + cellTag + "></" + cellTag + ">")
+			}
+
+			content, err := entry.Inline()
+			if err != nil {
+				return err
+			}
+
+			sb.WriteString("<" + cellTag)
+			if colspan := end - start + 1; colspan > 1 {
+				sb.WriteString(fmt.Sprintf(` colspan="%d"`, colspan))
+			}
+			moreRows, _ := strconv.Atoi(strings.TrimSpace(string(entry.MoreRows)))
+			if moreRows > 0 {
+				sb.WriteString(fmt.Sprintf(` rowspan="%d"`, moreRows+1))
+			}
+			sb.WriteString(">" + r.HTML(content) + "</" + cellTag + ">")
+
+			layout.occupy(start, end, moreRows)
+			column = layout.nextFreeColumn(end + 1)
+		}
+
+		sb.WriteString("</tr>")
+		layout.nextRow()
+	}
+
+	return nil
+}
+
+// Markdown renders the <table> as a Markdown table for each <tgroup>,
+// preceded by the <head> in bold.  The first <thead> row is the header row of
+// the Markdown table.  Markdown tables cannot span columns or rows, so an
+// <entry> is rendered in its first column and row, and the other cells that
+// it spans are empty.  <lb/> in an <entry> is rendered as a space.
+func (table *Table) Markdown() (string, error) {
+	blocks, err := table.markdownBlocks()
+	if err != nil {
+		return "", err
+	}
+	return joinMarkdownBlocks(blocks), nil
+}
+
+func (table *Table) markdownBlocks() ([]markdownBlock, error) {
+	var blocks []markdownBlock
+	if table.Head != nil {
+		head, err := markdownHeadingValue(table.Head)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, markdownHeadBlock(head)...)
+	}
+
+	for _, tgroup := range table.TGroup {
+		layout, err := newTableLayout(tgroup)
+		if err != nil {
+			return nil, err
+		}
+
+		var headRows, bodyRows [][]string
+		if tgroup.THead != nil {
+			headRows, err = layout.markdownRows(tgroup.THead.Row)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if tgroup.TBody != nil {
+			bodyRows, err = layout.markdownRows(tgroup.TBody.Row)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		columns := layout.columns
+		for _, row := range append(headRows, bodyRows...) {
+			if len(row) > columns {
+				columns = len(row)
+			}
+		}
+		if columns == 0 {
+			continue
+		}
+
+		header := make([]string, columns)
+		if len(headRows) > 0 {
+			copy(header, headRows[0])
+			bodyRows = append(headRows[1:], bodyRows...)
+		}
+
+		delimiters := make([]string, columns)
+		for i := range delimiters {
+			delimiters[i] = "---"
+		}
+
+		lines := []string{formatMarkdownTableRow(header, columns), formatMarkdownTableRow(delimiters, columns)}
+		for _, row := range bodyRows {
+			lines = append(lines, formatMarkdownTableRow(row, columns))
+		}
+		blocks = append(blocks, markdownBlock{Text: strings.Join(lines, "\n")})
+	}
+
+	return blocks, nil
+}
+
+func formatMarkdownTableRow(cells []string, columns int) string {
+	row := make([]string, columns)
+	copy(row, cells)
+	return "| " + strings.Join(row, " | ") + " |"
+}
+
+// markdownRows returns the Markdown cells of the rows, laid out like the
+// cells of TableHTML()
+func (layout *tableLayout) markdownRows(rows []*Row) ([][]string, error) {
+	// the <thead> and <tbody> rows are laid out separately
+	layout.occupied = map[int]int{}
+
+	var markdownRows [][]string
+	for _, row := range rows {
+		var cells []string
+
+		column := layout.nextFreeColumn(1)
+		for _, entry := range row.Entry {
+			start, end, err := layout.entryColumns(entry, column)
+			if err != nil {
+				return nil, err
+			}
+
+			content, err := entry.Inline()
+			if err != nil {
+				return nil, err
+			}
+
+			for len(cells) < end {
+				cells = append(cells, "")
+			}
+			// a cell cannot contain a line break, like a heading, and the
+			// pipe separates the cells
+			cells[start-1] = strings.ReplaceAll((&markdownWriter{}).renderHeading(content), "|", `\|`)
+
+			moreRows, _ := strconv.Atoi(strings.TrimSpace(string(entry.MoreRows)))
+			layout.occupy(start, end, moreRows)
+			column = layout.nextFreeColumn(end + 1)
+		}
+
+		markdownRows = append(markdownRows, cells)
+		layout.nextRow()
+	}
+
+	return markdownRows, nil
+}
+
+// tableLayout tracks the columns of a <tgroup> that are occupied by the
+// @morerows of the entries of previous rows
+type tableLayout struct {
+	// columns is the <tgroup> @cols, or 0 if it is missing or invalid
+	columns int
+	// columnNumbers maps the <colspec> @colname to the column number
+	columnNumbers map[string]int
+	// occupied maps column numbers to the number of following rows that
+	// the column is occupied for
+	occupied map[int]int
+}
+
+func newTableLayout(tgroup *TGroup) (*tableLayout, error) {
+	layout := &tableLayout{columnNumbers: map[string]int{}, occupied: map[int]int{}}
+
+	if n, err := strconv.Atoi(strings.TrimSpace(string(tgroup.Cols))); err == nil && n > 0 {
+		if n > MaxTableColumns {
+			return nil, fmt.Errorf("<tgroup> @cols %d is more than %d columns", n, MaxTableColumns)
+		}
+		layout.columns = n
+	}
+
+	// a <colspec> without a @colnum is the column after the previous <colspec>
+	var number int
+	for _, colSpec := range tgroup.ColSpec {
+		if n, err := strconv.Atoi(strings.TrimSpace(string(colSpec.ColNum))); err == nil && n > 0 {
+			number = n
+		} else {
+			number++
+		}
+		if colSpec.ColName != "" {
+			layout.columnNumbers[string(colSpec.ColName)] = number
+		}
+	}
+
+	return layout, nil
+}
+
+// entryColumns returns the first and last column of the entry.  Entries
+// without a valid @colname or @namest start at the column.  An entry past the
+// last column of the <tgroup> is an error.
+func (layout *tableLayout) entryColumns(entry *Entry, column int) (int, int, error) {
+	start, end := column, column
+	if n, ok := layout.columnNumbers[string(entry.NameSt)]; ok && n >= column {
+		start, end = n, n
+		if n, ok := layout.columnNumbers[string(entry.NameEnd)]; ok && n > start {
+			end = n
+		}
+	} else if n, ok := layout.columnNumbers[string(entry.ColName)]; ok && n >= column {
+		start, end = n, n
+	}
+
+	lastColumn := layout.columns
+	if lastColumn == 0 {
+		lastColumn = MaxTableColumns
+	}
+	if end > lastColumn {
+		return 0, 0, fmt.Errorf("<entry> in column %d is past the last column %d of the <tgroup>", end, lastColumn)
+	}
+	return start, end, nil
+}
+
+// nextFreeColumn returns the first column from the column on that is not
+// occupied by an entry of a previous row
+func (layout *tableLayout) nextFreeColumn(column int) int {
+	for layout.occupied[column] > 0 {
+		column++
+	}
+	return column
+}
+
+func (layout *tableLayout) occupy(start int, end int, moreRows int) {
+	if moreRows <= 0 {
+		return
+	}
+	for column := start; column <= end; column++ {
+		layout.occupied[column] = moreRows + 1
+	}
+}
+
+// nextRow releases the columns of the current row
+func (layout *tableLayout) nextRow() {
+	for column, rows := range layout.occupied {
+		if rows <= 1 {
+			delete(layout.occupied, column)
+		} else {
+			layout.occupied[column] = rows - 1
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package ead
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+func TestTableHTML(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"Simple", `<table><head>Boxes</head><tgroup cols="2"><thead><row><entry>Box</entry><entry>Contents</entry></row></thead>` +
+			`<tbody><row><entry>1</entry><entry>Letters &amp; <emph render="italic">diaries</emph></entry></row></tbody></tgroup></table>`,
+			`<table class="ead-table"><caption>Boxes</caption><thead><tr><th>Box</th><th>Contents</th></tr></thead>` +
+				`<tbody><tr><td>1</td><td>Letters &amp; <span class="ead-emph ead-emph-italic">diaries</span></td></tr></tbody></table>`},
+		{"MoreRows", `<table><tgroup cols="3"><tbody>` +
+			`<row><entry morerows="2">a</entry><entry>b</entry><entry>c</entry></row>` +
+			`<row><entry>d</entry><entry morerows="1">e</entry></row>` +
+			`<row><entry>f</entry></row>` +
+			`<row><entry>g</entry><entry>h</entry><entry>i</entry></row></tbody></tgroup></table>`,
+			`<table class="ead-table"><tbody>` +
+				`<tr><td rowspan="3">a</td><td>b</td><td>c</td></tr>` +
+				`<tr><td>d</td><td rowspan="2">e</td></tr>` +
+				`<tr><td>f</td></tr>` +
+				`<tr><td>g</td><td>h</td><td>i</td></tr></tbody></table>`},
+		{"NameSt NameEnd", `<table><tgroup cols="4"><colspec colname="c1"/><colspec colname="c2"/><colspec colname="c3"/><colspec colname="c4"/>` +
+			`<thead><row><entry namest="c1" nameend="c2">a</entry><entry namest="c3" nameend="c4">b</entry></row></thead>` +
+			`<tbody><row><entry>c</entry><entry namest="c2" nameend="c4" morerows="1">d</entry></row>` +
+			`<row><entry>e</entry></row></tbody></tgroup></table>`,
+			`<table class="ead-table"><thead><tr><th colspan="2">a</th><th colspan="2">b</th></tr></thead>` +
+				`<tbody><tr><td>c</td><td colspan="3" rowspan="2">d</td></tr>` +
+				`<tr><td>e</td></tr></tbody></table>`},
+		{"Skipped Columns", `<table><tgroup cols="3"><colspec colname="one" colnum="1"/><colspec colname="three" colnum="3"/>` +
+			`<tbody><row><entry colname="three">a</entry></row>` +
+			`<row><entry>b</entry><entry namest="three" nameend="unknown">c</entry></row></tbody></tgroup></table>`,
+			`<table class="ead-table"><tbody><tr><td></td><td></td><td>a</td></tr>` +
+				`<tr><td>b</td><td></td><td>c</td></tr></tbody></table>`},
+		{"Multiple TGroups", `<table><head>Head</head><tgroup cols="1"><tbody><row><entry>a</entry></row></tbody></tgroup>` +
+			`<tgroup cols="1"><tbody><row><entry>b</entry></row></tbody></tgroup></table>`,
+			`<table class="ead-table"><caption>Head</caption><tbody><tr><td>a</td></tr></tbody></table>` +
+				`<table class="ead-table"><tbody><tr><td>b</td></tr></tbody></table>`},
+	}
+
+	for _, tc := range testCases {
+		t.Run("Table HTML "+tc.name, func(t *testing.T) {
+			var table Table
+			err := xml.Unmarshal([]byte(tc.value), &table)
+			failOnError(t, err, "Unexpected error unmarshaling <table>")
+
+			got, err := table.HTML()
+			failOnError(t, err, "Unexpected error rendering <table>")
+			assertEqual(t, tc.want, got, "Table.HTML()")
+		})
+	}
+
+	t.Run("Table HTML Renderer", func(t *testing.T) {
+		var table Table
+		err := xml.Unmarshal([]byte(`<table><tgroup cols="1"><tbody><row><entry><emph render="bold">a</emph></entry></row></tbody></tgroup></table>`), &table)
+		failOnError(t, err, "Unexpected error unmarshaling <table>")
+
+		renderer := NewRenderer()
+		renderer.ClassPrefix = "fa-"
+		renderer.Elements["emph"] = ElementRendering{Tag: "em", RenderTags: map[string]string{"bold": "strong"}}
+
+		got, err := renderer.TableHTML(&table)
+		failOnError(t, err, "Unexpected error rendering <table>")
+		assertEqual(t, `<table class="fa-table"><tbody><tr><td><strong>a</strong></td></tr></tbody></table>`, got, "Renderer.TableHTML()")
+
+		renderer.ClassPrefix = `x" onclick="alert(1)`
+		got, err = renderer.TableHTML(&table)
+		failOnError(t, err, "Unexpected error rendering <table>")
+		assertEqual(t, `<table class="x&#34; onclick=&#34;alert(1)table"><tbody><tr><td><strong>a</strong></td></tr></tbody></table>`, got, "Renderer.TableHTML() escaped ClassPrefix")
+	})
+}
+
+func TestTableMarkdown(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"Simple", `<table><head>Boxes</head><tgroup cols="2"><thead><row><entry>Box</entry><entry>Contents</entry></row></thead>` +
+			`<tbody><row><entry>1</entry><entry>Letters &amp; <emph render="italic">diaries</emph></entry></row></tbody></tgroup></table>`,
+			"**Boxes**\n\n| Box | Contents |\n| --- | --- |\n| 1 | Letters & *diaries* |"},
+		{"No THead", `<table><tgroup cols="2"><tbody><row><entry>a|b</entry><entry>c<lb/>d</entry></row></tbody></tgroup></table>`,
+			"|  |  |\n| --- | --- |\n| a\\|b | c d |"},
+		{"Spans", `<table><tgroup cols="3"><colspec colname="c1"/><colspec colname="c2"/><colspec colname="c3"/>` +
+			`<thead><row><entry>a</entry><entry namest="c2" nameend="c3">b</entry></row></thead>` +
+			`<tbody><row><entry morerows="1">c</entry><entry>d</entry><entry>e</entry></row>` +
+			`<row><entry>f</entry></row></tbody></tgroup></table>`,
+			"| a | b |  |\n| --- | --- | --- |\n| c | d | e |\n|  | f |  |"},
+		{"Multiple TGroups", `<table><tgroup cols="1"><tbody><row><entry>a</entry></row></tbody></tgroup>` +
+			`<tgroup cols="1"><tbody><row><entry>b</entry></row></tbody></tgroup></table>`,
+			"|  |\n| --- |\n| a |\n\n|  |\n| --- |\n| b |"},
+	}
+
+	for _, tc := range testCases {
+		t.Run("Table Markdown "+tc.name, func(t *testing.T) {
+			var table Table
+			err := xml.Unmarshal([]byte(tc.value), &table)
+			failOnError(t, err, "Unexpected error unmarshaling <table>")
+
+			got, err := table.Markdown()
+			failOnError(t, err, "Unexpected error rendering <table>")
+			assertEqual(t, tc.want, got, "Table.Markdown()")
+		})
+	}
+}
+
+func TestTableColumnBounds(t *testing.T) {
+	testCases := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"ColNum Past MaxTableColumns", `<table><tgroup><colspec colname="far" colnum="50000"/>` +
+			`<tbody><row><entry colname="far">a</entry></row></tbody></tgroup></table>`,
+			"<entry> in column 50000 is past the last column 1000 of the <tgroup>"},
+		{"NameEnd Past Cols", `<table><tgroup cols="2"><colspec colname="c1"/><colspec colname="far" colnum="50000"/>` +
+			`<tbody><row><entry namest="c1" nameend="far">a</entry></row></tbody></tgroup></table>`,
+			"<entry> in column 50000 is past the last column 2 of the <tgroup>"},
+		{"Entries Past Cols", `<table><tgroup cols="1"><tbody><row><entry>a</entry><entry>b</entry></row></tbody></tgroup></table>`,
+			"<entry> in column 2 is past the last column 1 of the <tgroup>"},
+		{"Cols Past MaxTableColumns", `<table><tgroup cols="50000"><tbody><row><entry>a</entry></row></tbody></tgroup></table>`,
+			"<tgroup> @cols 50000 is more than 1000 columns"},
+	}
+
+	for _, tc := range testCases {
+		t.Run("Table Column Bounds "+tc.name, func(t *testing.T) {
+			var table Table
+			err := xml.Unmarshal([]byte(tc.value), &table)
+			failOnError(t, err, "Unexpected error unmarshaling <table>")
+
+			_, err = table.HTML()
+			if err == nil {
+				t.Fatalf("Expected an error rendering the <table> HTML")
+			}
+			assertEqual(t, tc.want, err.Error(), "Table.HTML() error")
+
+			_, err = table.Markdown()
+			if err == nil {
+				t.Fatalf("Expected an error rendering the <table> Markdown")
+			}
+			assertEqual(t, tc.want, err.Error(), "Table.Markdown() error")
+		})
+	}
+}
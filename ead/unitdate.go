@@ -0,0 +1,230 @@
+package ead
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	DateRangeSourceNormal = "normal"
+	DateRangeSourceText   = "text"
+)
+
+// DateRange is the parsed representation of a <unitdate>.
+//
+// Begin and End are ISO 8601 calendar dates with year, month, or day
+// precision: YYYY, YYYY-MM, or YYYY-MM-DD.  They are empty if the date
+// could not be determined, e.g., for "undated".
+//
+// Source indicates whether the range was derived from the @normal attribute
+// or from the element text.
+type DateRange struct {
+	Begin   string `json:"begin,omitempty"`
+	End     string `json:"end,omitempty"`
+	Bulk    bool   `json:"bulk,omitempty"`
+	Circa   bool   `json:"circa,omitempty"`
+	Undated bool   `json:"undated,omitempty"`
+	Source  string `json:"source"`
+}
+
+// BeginYear returns the year of Begin, or 0 if Begin is empty
+func (d *DateRange) BeginYear() int {
+	return dateYear(d.Begin)
+}
+
+// EndYear returns the year of End, or 0 if End is empty
+func (d *DateRange) EndYear() int {
+	return dateYear(d.End)
+}
+
+// IsDated returns true if the date range has a begin and end date
+func (d *DateRange) IsDated() bool {
+	return d.Begin != "" && d.End != ""
+}
+
+// DateRange parses the <unitdate>.
+//
+// The @normal attribute, an ISO 8601 date or interval, e.g., "1920/1935",
+// takes precedence.  If @normal is not present or is not valid, the dates
+// are parsed from common free-text forms, e.g., "1920-1935", "1920-35",
+// "circa 1950", "bulk 1960s", "1800s", "1981-08-31".
+//
+// The Circa and Undated flags are always derived from the text, and Bulk is
+// set if @type is "bulk" or the text contains "bulk".
+//
+// Returns nil if no date information can be derived.
+func (unitdate *UnitDate) DateRange() *DateRange {
+	text := strings.ToLower(getInlinePlainText(unitdate.Value))
+	// separate the words that run into the dates, e.g., "circa1910" for
+	// "<emph>circa</emph>1910", so that the dates are found
+	text = letterDigitRegexp.ReplaceAllString(text, "$1 $2")
+
+	dateRange := DateRange{
+		Bulk:    string(unitdate.Type) == "bulk" || strings.Contains(text, "bulk"),
+		Circa:   circaRegexp.MatchString(text),
+		Undated: undatedRegexp.MatchString(text),
+	}
+
+	if begin, end, ok := parseNormalDateInterval(string(unitdate.Normal)); ok {
+		dateRange.Begin = begin
+		dateRange.End = end
+		dateRange.Source = DateRangeSourceNormal
+		return &dateRange
+	}
+
+	if begin, end, ok := parseTextDateRange(text); ok {
+		dateRange.Begin = begin
+		dateRange.End = end
+		dateRange.Source = DateRangeSourceText
+		return &dateRange
+	}
+
+	if dateRange.Undated {
+		dateRange.Source = DateRangeSourceText
+		return &dateRange
+	}
+
+	return nil
+}
+
+var (
+	// ISO 8601 calendar dates, extended and basic formats
+	isoDateRegexp      = regexp.MustCompile(`^(\d{4})(?:-?(\d{2})(?:-?(\d{2}))?)?$`)
+	letterDigitRegexp  = regexp.MustCompile(`([a-z])(\d)`)
+	circaRegexp        = regexp.MustCompile(`\b(?:circa|ca\.|approximately|approx\.)|(?:^|\s)c\.\s*\d|\d\?`)
+	undatedRegexp      = regexp.MustCompile(`\bundated\b|\bn\.\s?d\.|\bno date\b`)
+	textFullDateRegexp = regexp.MustCompile(`\b(\d{4})-(\d{2})-(\d{2})\b`)
+	textShortRangeRe   = regexp.MustCompile(`\b(\d{4})\s*[-–—]\s*(\d{2})\b`)
+	textCenturyRegexp  = regexp.MustCompile(`\b(\d{2})00'?s\b`)
+	textDecadeRegexp   = regexp.MustCompile(`\b(\d{3})0'?s\b`)
+	textYearRegexp     = regexp.MustCompile(`\b(\d{4})\b`)
+)
+
+// parseNormalDateInterval parses an ISO 8601 date, e.g., "1920", or interval, e.g., "1920/1935"
+func parseNormalDateInterval(normal string) (string, string, bool) {
+	normal = strings.TrimSpace(normal)
+	if normal == "" {
+		return "", "", false
+	}
+
+	beginString, endString, isInterval := strings.Cut(normal, "/")
+	if !isInterval {
+		endString = beginString
+	}
+
+	begin, ok := normalizeISODate(beginString)
+	if !ok {
+		return "", "", false
+	}
+	end, ok := normalizeISODate(endString)
+	if !ok {
+		return "", "", false
+	}
+
+	if beginSortKey(begin) > endSortKey(end) {
+		return "", "", false
+	}
+
+	return begin, end, true
+}
+
+// normalizeISODate validates an ISO 8601 calendar date and converts it to the extended format
+func normalizeISODate(s string) (string, bool) {
+	m := isoDateRegexp.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return "", false
+	}
+
+	date := m[1]
+	if m[2] != "" {
+		month, _ := strconv.Atoi(m[2])
+		if month < 1 || month > 12 {
+			return "", false
+		}
+		date += "-" + m[2]
+	}
+	if m[3] != "" {
+		day, _ := strconv.Atoi(m[3])
+		if day < 1 || day > 31 {
+			return "", false
+		}
+		date += "-" + m[3]
+	}
+
+	return date, true
+}
+
+// parseTextDateRange returns the earliest and latest dates found in the text
+func parseTextDateRange(text string) (string, string, bool) {
+	var begin, end string
+
+	add := func(b string, e string) {
+		if begin == "" || beginSortKey(b) < beginSortKey(begin) {
+			begin = b
+		}
+		if end == "" || endSortKey(e) > endSortKey(end) {
+			end = e
+		}
+	}
+
+	// each pattern consumes its matches so that, e.g., the "1981" in
+	// "1981-08-31" is not also treated as a year
+	for _, m := range textFullDateRegexp.FindAllStringSubmatch(text, -1) {
+		if date, ok := normalizeISODate(m[0]); ok {
+			add(date, date)
+		}
+	}
+	text = textFullDateRegexp.ReplaceAllString(text, " ")
+
+	// "1920-35" is a range, but "1920-12" is not: it is left for the year
+	// pattern
+	text = textShortRangeRe.ReplaceAllStringFunc(text, func(s string) string {
+		m := textShortRangeRe.FindStringSubmatch(s)
+		endYear := m[1][:2] + m[2]
+		if endYear <= m[1] {
+			return s
+		}
+		add(m[1], endYear)
+		return " "
+	})
+
+	// "1800s" is the century, not the decade 1800-1809
+	for _, m := range textCenturyRegexp.FindAllStringSubmatch(text, -1) {
+		add(m[1]+"00", m[1]+"99")
+	}
+	text = textCenturyRegexp.ReplaceAllString(text, " ")
+
+	for _, m := range textDecadeRegexp.FindAllStringSubmatch(text, -1) {
+		add(m[1]+"0", m[1]+"9")
+	}
+	text = textDecadeRegexp.ReplaceAllString(text, " ")
+
+	for _, m := range textYearRegexp.FindAllStringSubmatch(text, -1) {
+		add(m[1], m[1])
+	}
+
+	return begin, end, begin != ""
+}
+
+// The sort keys expand dates to day precision so that dates of different
+// precision can be compared: as a begin date, "1920" is "1920-01-01", and as
+// an end date, "1920" is "1920-12-31".
+func beginSortKey(date string) string {
+	return date + "-01-01"[len(date)-4:]
+}
+
+func endSortKey(date string) string {
+	return date + "-12-31"[len(date)-4:]
+}
+
+func dateYear(date string) int {
+	if len(date) < 4 {
+		return 0
+	}
+	year, err := strconv.Atoi(date[:4])
+	if err != nil {
+		return 0
+	}
+	return year
+}
@@ -0,0 +1,113 @@
+package ead
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func assertDateRange(t *testing.T, want *DateRange, got *DateRange, label string) {
+	if want == nil || got == nil {
+		if want != got {
+			t.Errorf("%s: want %+v, got %+v", label, want, got)
+		}
+		return
+	}
+	if *want != *got {
+		t.Errorf("%s: want %+v, got %+v", label, *want, *got)
+	}
+}
+
+func TestUnitDateDateRange(t *testing.T) {
+	testCases := []struct {
+		label    string
+		unitDate UnitDate
+		want     *DateRange
+	}{
+		{"@normal interval", UnitDate{Value: "1920-1935", Normal: "1920/1935"},
+			&DateRange{Begin: "1920", End: "1935", Source: DateRangeSourceNormal}},
+		{"@normal single date", UnitDate{Normal: "1981-08-31"},
+			&DateRange{Begin: "1981-08-31", End: "1981-08-31", Source: DateRangeSourceNormal}},
+		{"@normal basic format", UnitDate{Normal: "19810831/198109"},
+			&DateRange{Begin: "1981-08-31", End: "1981-09", Source: DateRangeSourceNormal}},
+		{"@normal takes precedence over text", UnitDate{Value: "1950s", Normal: "1952/1958"},
+			&DateRange{Begin: "1952", End: "1958", Source: DateRangeSourceNormal}},
+		{"@type bulk", UnitDate{Value: "1960-1965", Type: "bulk", Normal: "1960/1965"},
+			&DateRange{Begin: "1960", End: "1965", Bulk: true, Source: DateRangeSourceNormal}},
+		{"invalid @normal falls back to text", UnitDate{Value: "1920-1935", Normal: "1935/1920"},
+			&DateRange{Begin: "1920", End: "1935", Source: DateRangeSourceText}},
+		{"text range", UnitDate{Value: "1920-1935"},
+			&DateRange{Begin: "1920", End: "1935", Source: DateRangeSourceText}},
+		{"text short range", UnitDate{Value: "1920-35"},
+			&DateRange{Begin: "1920", End: "1935", Source: DateRangeSourceText}},
+		{"text year and month", UnitDate{Value: "2018-02"},
+			&DateRange{Begin: "2018", End: "2018", Source: DateRangeSourceText}},
+		{"text year and month that is not a short range", UnitDate{Value: "1920-12"},
+			&DateRange{Begin: "1920", End: "1920", Source: DateRangeSourceText}},
+		{"text circa", UnitDate{Value: "circa 1950"},
+			&DateRange{Begin: "1950", End: "1950", Circa: true, Source: DateRangeSourceText}},
+		{"text ca.", UnitDate{Value: "ca. 1950-1955"},
+			&DateRange{Begin: "1950", End: "1955", Circa: true, Source: DateRangeSourceText}},
+		{"text bulk decade", UnitDate{Value: "bulk 1960s"},
+			&DateRange{Begin: "1960", End: "1969", Bulk: true, Source: DateRangeSourceText}},
+		{"text decade with apostrophe", UnitDate{Value: "1950's"},
+			&DateRange{Begin: "1950", End: "1959", Source: DateRangeSourceText}},
+		{"text century", UnitDate{Value: "1800s"},
+			&DateRange{Begin: "1800", End: "1899", Source: DateRangeSourceText}},
+		{"text century and decade", UnitDate{Value: "late 1800s-1910s"},
+			&DateRange{Begin: "1800", End: "1919", Source: DateRangeSourceText}},
+		{"text ISO date", UnitDate{Value: "1981-08-31"},
+			&DateRange{Begin: "1981-08-31", End: "1981-08-31", Source: DateRangeSourceText}},
+		{"text mixed precision", UnitDate{Value: "1981, 1981-08-31"},
+			&DateRange{Begin: "1981", End: "1981", Source: DateRangeSourceText}},
+		{"text month names", UnitDate{Value: "1869 November-1873 December"},
+			&DateRange{Begin: "1869", End: "1873", Source: DateRangeSourceText}},
+		{"text multiple ranges and undated", UnitDate{Value: "1921, 1942-1946, 1953-1958, and undated"},
+			&DateRange{Begin: "1921", End: "1958", Undated: true, Source: DateRangeSourceText}},
+		{"text with tags", UnitDate{Value: `<emph render="italic">circa</emph> 1910`},
+			&DateRange{Begin: "1910", End: "1910", Circa: true, Source: DateRangeSourceText}},
+		{"text with tags and no space", UnitDate{Value: `<emph render="italic">circa</emph>1910`},
+			&DateRange{Begin: "1910", End: "1910", Circa: true, Source: DateRangeSourceText}},
+		{"undated", UnitDate{Value: "Undated"},
+			&DateRange{Undated: true, Source: DateRangeSourceText}},
+		{"no date information", UnitDate{Value: "\n    "}, nil},
+		{"no date information with text", UnitDate{Value: "Various"}, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.label, func(t *testing.T) {
+			assertDateRange(t, tc.want, tc.unitDate.DateRange(), tc.label)
+		})
+	}
+}
+
+func TestUnitDateDateRangeFixture(t *testing.T) {
+	t.Run("UnitDate DateRange Fixture", func(t *testing.T) {
+		sut := getTestEAD(t, filepath.Join(tamwagTestFixturePath, "mos_2021-with-test-unitdates.xml"))
+
+		want := []*DateRange{
+			{Begin: "2016", End: "2021", Undated: true, Source: DateRangeSourceNormal},
+			{Begin: "1910", End: "1910", Circa: true, Source: DateRangeSourceNormal},
+			{Begin: "1977", End: "2000", Source: DateRangeSourceNormal},
+			{Begin: "2013", End: "2013", Source: DateRangeSourceNormal},
+			nil,
+			{Begin: "1914", End: "2014", Source: DateRangeSourceNormal},
+			{Begin: "2018-02-08", End: "2018-02-08", Source: DateRangeSourceNormal},
+		}
+
+		got := sut.ArchDesc.DID.UnitDate
+		if len(got) != len(want) {
+			t.Fatalf("Expected %d <unitdate> elements, got %d", len(want), len(got))
+		}
+		for i := range want {
+			assertDateRange(t, want[i], got[i].DateRange(), string(got[i].Normal))
+		}
+
+		dateRange := got[0].DateRange()
+		if dateRange.BeginYear() != 2016 || dateRange.EndYear() != 2021 {
+			t.Errorf("Expected years 2016-2021, got %d-%d", dateRange.BeginYear(), dateRange.EndYear())
+		}
+		if !dateRange.IsDated() {
+			t.Errorf("Expected date range to be dated")
+		}
+	})
+}
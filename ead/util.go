@@ -2,10 +2,8 @@ package ead
 
 import (
 	"encoding/json"
-	"encoding/xml"
 	"fmt"
 	"html"
-	"io"
 	"regexp"
 	"strings"
 	"time"
@@ -288,160 +286,14 @@ func GetConvertedTextWithTags(text string) ([]byte, error) {
 	return getConvertedTextWithTags(text)
 }
 func getConvertedTextWithTags(text string) ([]byte, error) {
-	return _getConvertedTextWithTags(text, true)
+	return defaultRenderer.convertTextWithTags(text)
 }
 
 func GetConvertedTextWithTagsNoLBConversion(text string) ([]byte, error) {
 	return getConvertedTextWithTagsNoLBConversion(text)
 }
 func getConvertedTextWithTagsNoLBConversion(text string) ([]byte, error) {
-	return _getConvertedTextWithTags(text, false)
-}
-
-func _getConvertedTextWithTags(text string, convertLBTags bool) ([]byte, error) {
-	decoder := xml.NewDecoder(strings.NewReader(text))
-
-	var result string
-	needClosingTag := true
-	unit := ""
-	for {
-		token, err := decoder.Token()
-
-		if err == io.EOF {
-			break
-		} else if err != nil {
-			return nil, err
-		}
-
-		switch token := token.(type) {
-		case xml.StartElement:
-			switch token.Name.Local {
-			default:
-				result += _getConvertedTextWithTagsDefault(token.Name.Local)
-			case "emph":
-				{
-					var render string
-					for i := range token.Attr {
-						if token.Attr[i].Name.Local == "render" {
-							render = token.Attr[i].Value
-							break
-						}
-					}
-
-					if render == "" {
-						result += fmt.Sprintf("<span class=\"%s\">", "ead-emph")
-					} else {
-						result += fmt.Sprintf("<span class=\"%s\">", "ead-emph ead-emph-"+render)
-					}
-				}
-			case "lb":
-				{
-					if convertLBTags {
-						result += "<br>"
-						needClosingTag = false
-					} else {
-						result += _getConvertedTextWithTagsDefault(token.Name.Local)
-					}
-				}
-			case "extent":
-				{
-					// if there is a "unit" attribute, save the
-					// unit attribute value to append to the result
-					for i := range token.Attr {
-						if token.Attr[i].Name.Local == "unit" {
-							unit = token.Attr[i].Value
-						}
-
-					}
-					// default processing of opening tag
-					result += _getConvertedTextWithTagsDefault(token.Name.Local)
-				}
-			case "extref":
-				{
-					var href string
-					var target string
-
-					for i := range token.Attr {
-						if token.Attr[i].Name.Local == "href" {
-							href = token.Attr[i].Value
-						}
-						if token.Attr[i].Name.Local == "show" {
-							target = token.Attr[i].Value
-						}
-					}
-					result += fmt.Sprintf("<a class=\"%s\" href=\"%s\" target=\"%s\">", "ead-extref", href, target)
-				}
-			case "ref":
-				{
-					var href string
-					var target string
-
-					for i := range token.Attr {
-						if token.Attr[i].Name.Local == "href" {
-							href = token.Attr[i].Value
-						}
-						if token.Attr[i].Name.Local == "show" {
-							target = token.Attr[i].Value
-						}
-					}
-					result += fmt.Sprintf("<a class=\"%s\" href=\"%s\" target=\"%s\">", "ead-ref", href, target)
-				}
-			case "title":
-				{
-					var render string
-					for i := range token.Attr {
-						if token.Attr[i].Name.Local == "render" {
-							render = token.Attr[i].Value
-							break
-						}
-					}
-					if render == "" {
-						result += fmt.Sprintf("<span class=\"%s\">", "ead-title")
-					} else {
-						// NOTE: the use of ead-emph-"+render below is INTENTIONAL
-						// This eliminates the need for per-element selectors for the render attribute
-						result += fmt.Sprintf("<span class=\"%s\">", "ead-title ead-emph-"+render)
-					}
-				}
-			}
-
-		case xml.EndElement:
-			// Add "unit" attribute value to extent if present
-			if token.Name.Local == "extent" {
-				if unit != "" {
-					result += fmt.Sprintf(" %s", unit)
-				}
-				// reset unit
-				unit = ""
-			}
-
-			if token.Name.Local == "extref" {
-				result += "</a>"
-				needClosingTag = false
-			}
-
-			if token.Name.Local == "ref" {
-				result += "</a>"
-				needClosingTag = false
-			}
-
-			if needClosingTag {
-				result += "</span>"
-			} else {
-				// Reset
-				needClosingTag = true
-			}
-
-		case xml.CharData:
-			result += strings.ReplaceAll(string(token), "\n", " ")
-		}
-	}
-
-	return []byte(cleanupWhitespace(result)), nil
-}
-
-func _getConvertedTextWithTagsDefault(tagName string) string {
-	return fmt.Sprintf("<span class=\"ead-%s\">", tagName)
+	return defaultRenderer.convertTextWithTagsNoLBConversion(text)
 }
 
 func getRelatorAuthoritativeLabel(relatorID string) (string, error) {
@@ -513,9 +365,13 @@ func (s FilteredString) MarshalJSON() ([]byte, error) {
 	return json.Marshal(s.String())
 }
 
+// cleanupWhitespace unescapes HTML entities and collapses the whitespace.
+// Use collapseWhitespace for rendered HTML, which must stay escaped.
 func cleanupWhitespace(inputString string) string {
-	s := html.UnescapeString(inputString)
+	return collapseWhitespace(html.UnescapeString(inputString))
+}
 
+func collapseWhitespace(s string) string {
 	// find occurrences of one or more consecutive \r, \n, \t, " "
 	re := regexp.MustCompile(`\r+|\n+|\t+|( )+`)
 	// replace occurences with a single space
@@ -528,6 +384,15 @@ func cleanupWhitespace(inputString string) string {
 	return result
 }
 
+var tagRegexp = regexp.MustCompile(`<[^>]*>`)
+
+// getPlainText replaces the tags of mixed content with spaces, so that,
+// e.g., "<emph>circa</emph>1910" is not joined into one word, unescapes
+// entities, and cleans up the whitespace
+func getPlainText(value string) string {
+	return collapseWhitespace(html.UnescapeString(tagRegexp.ReplaceAllString(value, " ")))
+}
+
 type FilteredLabelString FilteredString
 
 func (s FilteredLabelString) MarshalJSON() ([]byte, error) {
@@ -535,6 +400,18 @@ func (s FilteredLabelString) MarshalJSON() ([]byte, error) {
 	return json.Marshal(cleanupWhitespace(removeBracketedText(string(s))))
 }
 
+// FilteredURLString is a FilteredString with a URL, e.g., an @href.  A URL
+// that is not safe, see IsSafeURL(), is marshaled as an empty string.
+type FilteredURLString FilteredString
+
+func (s FilteredURLString) MarshalJSON() ([]byte, error) {
+	href := FilteredString(s).String()
+	if !IsSafeURL(href) {
+		href = ""
+	}
+	return json.Marshal(href)
+}
+
 func removeBracketedText(s string) string {
 	// find bracketed text
 	re := regexp.MustCompile(`\[.+\]`)
@@ -544,7 +421,7 @@ func removeBracketedText(s string) string {
 }
 
 func flattenCDATA(cdata CDATA) ([]byte, error) {
-	return getConvertedTextWithTags(cdata.Value)
+	return cdata.htmlRenderer().convertTextWithTags(cdata.Value)
 }
 
 func flattenTitleProper(titleProper []*TitleProper) ([]byte, error) {
@@ -565,7 +442,7 @@ func flattenTitleProper(titleProper []*TitleProper) ([]byte, error) {
 		return nil, fmt.Errorf("unable to find correct title")
 	}
 
-	return getConvertedTextWithTagsNoLBConversion(titleToFlatten.Value)
+	return titleToFlatten.htmlRenderer().convertTextWithTagsNoLBConversion(titleToFlatten.Value)
 }
 
 func (e *EAD) GuideTitle() string {
@@ -596,18 +473,18 @@ func (e *EAD) EADID() string {
 
 // process an array of components
 func CountCsDAOs(cs []*C, daoInfo *DAOInfo) {
-	for _, c := range cs {
-		CountCDAOs(c, daoInfo)
-	}
+	// configured to perform a pre-order aggregation: a component's DAOs
+	//   are counted before the DAOs of its children.
+	//   use a Visitor with a Post function for a post-order aggregation.
+	WalkCs(cs, func(c *C, info *WalkInfo) error {
+		CountDIDDAOs(&c.DID, daoInfo)
+		return nil
+	})
 }
 
 // process a component
 func CountCDAOs(c *C, daoInfo *DAOInfo) {
-	// configured to perform a breadth-first aggregation.
-	// switch the CountDIDDAOs/CountCsDAOs order if you want to do
-	//   a depth-first aggregation.
-	CountDIDDAOs(&c.DID, daoInfo)
-	CountCsDAOs(c.C, daoInfo)
+	CountCsDAOs([]*C{c}, daoInfo)
 }
 
 func CountDIDDAOs(did *DID, daoInfo *DAOInfo) {
@@ -704,15 +581,12 @@ func CountDAOGrps(daoGrps []*DAOGrp, daoGrpInfo *DAOGrpInfo) {
 
 // process an array of components
 func CountCsDAOGrps(cs []*C, daoGrpInfo *DAOGrpInfo) {
-	for _, c := range cs {
-		countCDAOGrps(c, daoGrpInfo)
-	}
-}
-
-// process a component
-func countCDAOGrps(c *C, daoGrpInfo *DAOGrpInfo) {
-	CountCsDAOGrps(c.C, daoGrpInfo)
-	CountDAOGrps(c.DID.DAOGrp, daoGrpInfo)
+	// post-order aggregation: a component's DAOGrps are counted
+	//   after the DAOGrps of its children
+	VisitCs(cs, Visitor{Post: func(c *C, info *WalkInfo) error {
+		CountDAOGrps(c.DID.DAOGrp, daoGrpInfo)
+		return nil
+	}})
 }
 
 func appendDAOGrp(daoGrp *DAOGrp, daoGrpSlice *[]*DAOGrp) {
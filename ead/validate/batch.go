@@ -0,0 +1,147 @@
+package validate
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// BatchResult is the outcome of validating a single file in a batch.
+// Err is set if the file could not be validated, e.g., if it could not be read.
+type BatchResult struct {
+	Path   string
+	Result *ValidationResult
+	Err    error
+}
+
+// ValidateBatch validates the EAD files in paths using a pool of workers.
+//
+// The EAD schema is parsed once and shared by all workers, instead of being
+// parsed for every file.  If workers is less than 1, runtime.NumCPU() workers
+// are used.
+//
+// Results are sent on the returned channel as each file is validated, so they
+// are not necessarily in the same order as paths.  The channel is closed when
+// all files have been validated or ctx is cancelled.  Callers must either
+// drain the channel or cancel ctx.
+//
+// An error is returned if the EAD schema cannot be parsed.
+func (v *Validator) ValidateBatch(ctx context.Context, paths []string, workers int) (<-chan BatchResult, error) {
+	return v.validateBatch(ctx, workers, func(ctx context.Context, files chan<- string, results chan<- BatchResult) {
+		for _, path := range paths {
+			select {
+			case files <- path:
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+}
+
+// ValidateDirectory validates all *.xml files in the dir directory tree using a pool of workers.
+// Files are validated while the directory tree is being walked.
+// Errors encountered while walking the directory tree are sent as a
+// BatchResult with Err set.
+//
+// See ValidateBatch for details on the workers, ctx, and the returned channel.
+func (v *Validator) ValidateDirectory(ctx context.Context, dir string, workers int) (<-chan BatchResult, error) {
+	return v.validateBatch(ctx, workers, func(ctx context.Context, files chan<- string, results chan<- BatchResult) {
+		filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				select {
+				case results <- BatchResult{Path: path, Err: err}:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+				if d != nil && d.IsDir() {
+					return fs.SkipDir
+				}
+				return nil
+			}
+
+			if d.IsDir() || !strings.EqualFold(filepath.Ext(path), ".xml") {
+				return nil
+			}
+
+			select {
+			case files <- path:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	})
+}
+
+// produceFunc sends the files to validate.  It must return when ctx is cancelled.
+type produceFunc func(ctx context.Context, files chan<- string, results chan<- BatchResult)
+
+func (v *Validator) validateBatch(ctx context.Context, workers int, produce produceFunc) (<-chan BatchResult, error) {
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+
+	// use a copy so that the parsed schema is not visible to other
+	// goroutines using v, and so that it can be freed when the batch is done
+	batch := *v
+	if batch.hasSchemaRule() {
+		eadxsd, err := parseEADSchema()
+		if err != nil {
+			return nil, err
+		}
+		batch.schema = eadxsd
+	}
+
+	files := make(chan string)
+	results := make(chan BatchResult)
+
+	var producer sync.WaitGroup
+	producer.Add(1)
+	go func() {
+		defer producer.Done()
+		defer close(files)
+		produce(ctx, files, results)
+	}()
+
+	var pool sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		pool.Add(1)
+		go func() {
+			defer pool.Done()
+			for path := range files {
+				if ctx.Err() != nil {
+					return
+				}
+				result, err := batch.ValidateFile(path)
+				select {
+				case results <- BatchResult{Path: path, Result: result, Err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		pool.Wait()
+		producer.Wait()
+		if batch.schema != nil {
+			batch.schema.Free()
+		}
+		close(results)
+	}()
+
+	return results, nil
+}
+
+func (v *Validator) hasSchemaRule() bool {
+	for _, r := range append(append([]Rule{}, v.Prerequisites...), v.Rules...) {
+		if r.Name() == RuleSchema {
+			return true
+		}
+	}
+	return false
+}
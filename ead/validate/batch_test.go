@@ -0,0 +1,173 @@
+package validate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func getFixturePaths(t testing.TB) []string {
+	paths, err := filepath.Glob(filepath.Join(fixturesDirPath, "*.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func TestValidateDirectoryMatchesValidateFile(t *testing.T) {
+	validator := NewDefaultValidator()
+
+	results, err := validator.ValidateDirectory(context.Background(), fixturesDirPath, 4)
+	failOnError(t, err)
+
+	got := map[string]BatchResult{}
+	for r := range results {
+		if _, ok := got[r.Path]; ok {
+			t.Errorf("Duplicate result for %s", r.Path)
+		}
+		got[r.Path] = r
+	}
+
+	paths := getFixturePaths(t)
+	if len(got) != len(paths) {
+		t.Fatalf("Expected %d results, got %d", len(paths), len(got))
+	}
+
+	for _, path := range paths {
+		r, ok := got[path]
+		if !ok {
+			t.Errorf("Missing result for %s", path)
+			continue
+		}
+		failOnError(t, r.Err)
+
+		want, err := validator.ValidateFile(path)
+		failOnError(t, err)
+
+		assertEqual(t, strings.Join(want.Messages(), "\n"), strings.Join(r.Result.Messages(), "\n"), filepath.Base(path))
+		if want.Valid() != r.Result.Valid() {
+			t.Errorf("%s: expected Valid() %t, got %t", filepath.Base(path), want.Valid(), r.Result.Valid())
+		}
+	}
+}
+
+func TestValidateBatchReportsFileErrors(t *testing.T) {
+	missingFilePath := filepath.Join(fixturesDirPath, "no-such-file.xml")
+
+	results, err := NewDefaultValidator().ValidateBatch(context.Background(), []string{validEADFixturePath, missingFilePath}, 0)
+	failOnError(t, err)
+
+	var count int
+	for r := range results {
+		count++
+		switch r.Path {
+		case validEADFixturePath:
+			failOnError(t, r.Err)
+			if !r.Result.Valid() {
+				t.Errorf("Expected %s to be valid, got %v", r.Path, r.Result.Issues)
+			}
+		case missingFilePath:
+			if !os.IsNotExist(r.Err) {
+				t.Errorf("Expected a file not found error for %s, got %v", r.Path, r.Err)
+			}
+		default:
+			t.Errorf("Unexpected result for %s", r.Path)
+		}
+	}
+
+	if count != 2 {
+		t.Errorf("Expected 2 results, got %d", count)
+	}
+}
+
+func TestValidateBatchCancellation(t *testing.T) {
+	var paths []string
+	for i := 0; i < 100; i++ {
+		paths = append(paths, validEADFixturePath)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	results, err := NewDefaultValidator().ValidateBatch(ctx, paths, 2)
+	failOnError(t, err)
+
+	<-results
+	cancel()
+
+	// the channel must be closed without the remaining files being validated
+	var count = 1
+	for range results {
+		count++
+	}
+
+	if count >= len(paths) {
+		t.Errorf("Expected fewer than %d results after cancellation, got %d", len(paths), count)
+	}
+}
+
+func TestValidateBatchWithoutSchemaRule(t *testing.T) {
+	validator := NewDefaultValidator()
+	validator.RemoveRule(RuleSchema)
+
+	results, err := validator.ValidateBatch(context.Background(), []string{invalidEADWithNamespaceErrorsFixturePath}, 1)
+	failOnError(t, err)
+
+	for r := range results {
+		failOnError(t, r.Err)
+		for _, issue := range r.Result.Issues {
+			if issue.Code == CodeSchemaInvalid || issue.Code == CodeSchemaViolation {
+				t.Errorf("Unexpected schema issue: %v", issue)
+			}
+		}
+	}
+}
+
+// BenchmarkValidatePerFileSchemaParsing validates each file with ValidateFile(),
+// which parses the EAD schema for every file
+func BenchmarkValidatePerFileSchemaParsing(b *testing.B) {
+	paths := getFixturePaths(b)
+	validator := NewDefaultValidator()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, path := range paths {
+			_, err := validator.ValidateFile(path)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// BenchmarkValidateBatchSharedSchema validates the same files with a single
+// worker, so that the difference from BenchmarkValidatePerFileSchemaParsing
+// is the cost of parsing the schema for every file
+func BenchmarkValidateBatchSharedSchema(b *testing.B) {
+	benchmarkValidateBatch(b, 1)
+}
+
+// BenchmarkValidateBatchWorkerPool validates the same files with runtime.NumCPU() workers
+func BenchmarkValidateBatchWorkerPool(b *testing.B) {
+	benchmarkValidateBatch(b, 0)
+}
+
+func benchmarkValidateBatch(b *testing.B, workers int) {
+	paths := getFixturePaths(b)
+	validator := NewDefaultValidator()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		results, err := validator.ValidateBatch(context.Background(), paths, workers)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for r := range results {
+			if r.Err != nil {
+				b.Fatal(r.Err)
+			}
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package validate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Profile is the file representation of a validation profile.
+// Profiles can be written in YAML or JSON, e.g.,
+//
+//	valid_repository_names:
+//	  - "Fales Library and Special Collections"
+//	  - "Partner Archives"
+//	eadid_pattern: "^[a-z0-9]+(?:_[a-z0-9]+){1,}$"
+//	eadid_character_analysis: true
+//	maximum_eadid_length: 251
+//	maximum_file_size: 100000000
+//	archdesc_required_level: collection
+//	rules:
+//	  repository: false
+//
+// Any setting that is not present in the profile falls back to the
+// compiled-in value from DefaultConfig().  All rules are enabled by default,
+// and can be disabled by setting them to false in the "rules" map.
+// A profile eadid_pattern disables the FADESIGN eadid_character_analysis,
+// unless the analysis is enabled in the profile.
+type Profile struct {
+	ValidRepositoryNames   *[]string       `yaml:"valid_repository_names" json:"valid_repository_names,omitempty"`
+	EADIDRegexpString      *string         `yaml:"eadid_pattern" json:"eadid_pattern,omitempty"`
+	EADIDCharacterAnalysis *bool           `yaml:"eadid_character_analysis" json:"eadid_character_analysis,omitempty"`
+	MaximumEADIDLength     *int            `yaml:"maximum_eadid_length" json:"maximum_eadid_length,omitempty"`
+	MaximumFileSize        *int64          `yaml:"maximum_file_size" json:"maximum_file_size,omitempty"`
+	ArchDescRequiredLevel  *string         `yaml:"archdesc_required_level" json:"archdesc_required_level,omitempty"`
+	Rules                  map[string]bool `yaml:"rules" json:"rules,omitempty"`
+}
+
+// ReadProfile reads a YAML or JSON validation profile.
+// Unknown settings are rejected so that typos do not silently fall back to defaults.
+func ReadProfile(r io.Reader) (*Profile, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var profile Profile
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	// YAML is a superset of JSON, so the YAML decoder handles both formats
+	err = decoder.Decode(&profile)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("malformed validation profile: %w", err)
+	}
+
+	err = profile.check()
+	if err != nil {
+		return nil, fmt.Errorf("invalid validation profile: %w", err)
+	}
+
+	return &profile, nil
+}
+
+// ReadProfileFromFile reads a YAML or JSON validation profile from a file
+func ReadProfileFromFile(filepath string) (*Profile, error) {
+	f, err := os.Open(filepath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	profile, err := ReadProfile(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath, err)
+	}
+
+	return profile, nil
+}
+
+// Config returns the profile settings merged with DefaultConfig()
+func (p *Profile) Config() Config {
+	config := DefaultConfig()
+
+	if p.ValidRepositoryNames != nil {
+		config.ValidRepositoryNames = append([]string{}, *p.ValidRepositoryNames...)
+	}
+	if p.EADIDRegexpString != nil {
+		config.EADIDRegexpString = *p.EADIDRegexpString
+		config.EADIDCharacterAnalysis = false
+	}
+	if p.EADIDCharacterAnalysis != nil {
+		config.EADIDCharacterAnalysis = *p.EADIDCharacterAnalysis
+	}
+	if p.MaximumEADIDLength != nil {
+		config.MaximumEADIDLength = *p.MaximumEADIDLength
+	}
+	if p.MaximumFileSize != nil {
+		config.MaximumFileSize = *p.MaximumFileSize
+	}
+	if p.ArchDescRequiredLevel != nil {
+		config.ArchDescRequiredLevel = *p.ArchDescRequiredLevel
+	}
+
+	return config
+}
+
+// NewValidator returns a Validator that uses the default rules, minus any
+// rules disabled in the profile, with the profile Config
+func (p *Profile) NewValidator() (*Validator, error) {
+	err := p.check()
+	if err != nil {
+		return nil, fmt.Errorf("invalid validation profile: %w", err)
+	}
+
+	validator := NewValidator(p.Config())
+
+	// sort for deterministic processing
+	var names []string
+	for name := range p.Rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !p.Rules[name] {
+			validator.RemoveRule(name)
+		}
+	}
+
+	return validator, nil
+}
+
+func (p *Profile) check() error {
+	if p.EADIDRegexpString != nil {
+		if _, err := regexp.Compile(*p.EADIDRegexpString); err != nil {
+			return fmt.Errorf("eadid_pattern %q is not a valid regular expression: %s", *p.EADIDRegexpString, err)
+		}
+	}
+
+	if p.MaximumEADIDLength != nil && *p.MaximumEADIDLength < 0 {
+		return fmt.Errorf("maximum_eadid_length must not be negative: %d", *p.MaximumEADIDLength)
+	}
+
+	if p.MaximumFileSize != nil && *p.MaximumFileSize < 0 {
+		return fmt.Errorf("maximum_file_size must not be negative: %d", *p.MaximumFileSize)
+	}
+
+	if p.ValidRepositoryNames != nil {
+		for i, name := range *p.ValidRepositoryNames {
+			if strings.TrimSpace(name) == "" {
+				return fmt.Errorf("valid_repository_names entry %d is blank", i+1)
+			}
+		}
+	}
+
+	knownRules := map[string]bool{}
+	for _, r := range append(DefaultPrerequisites(), DefaultRules()...) {
+		knownRules[r.Name()] = true
+	}
+
+	var unknownRules []string
+	for name := range p.Rules {
+		if !knownRules[name] {
+			unknownRules = append(unknownRules, fmt.Sprintf("%q", name))
+		}
+	}
+	if len(unknownRules) > 0 {
+		sort.Strings(unknownRules)
+		return fmt.Errorf("unknown rule(s) %s", strings.Join(unknownRules, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package validate
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func getProfileFixturePath(filename string) string {
+	return filepath.Join(fixturesDirPath, "..", "profiles", filename)
+}
+
+func TestReadProfileFromFilePartnerProfile(t *testing.T) {
+	for _, filename := range []string{"partner.yaml", "partner.json"} {
+		t.Run(filename, func(t *testing.T) {
+			profile, err := ReadProfileFromFile(getProfileFixturePath(filename))
+			failOnError(t, err)
+
+			config := profile.Config()
+			if len(config.ValidRepositoryNames) != 1 || config.ValidRepositoryNames[0] != "NYU Archives" {
+				t.Errorf("Unexpected ValidRepositoryNames: %v", config.ValidRepositoryNames)
+			}
+			if config.MaximumFileSize != 50_000_000 {
+				t.Errorf("Expected MaximumFileSize 50000000, got %d", config.MaximumFileSize)
+			}
+
+			// settings not present in the profile fall back to the compiled-in values
+			assertEqual(t, ValidEADIDRegexpString, config.EADIDRegexpString, "EADIDRegexpString")
+			assertEqual(t, ARCHDESC_REQUIRED_LEVEL, config.ArchDescRequiredLevel, "ArchDescRequiredLevel")
+			if config.MaximumEADIDLength != MAXIMUM_EADID_LENGTH {
+				t.Errorf("Expected MaximumEADIDLength %d, got %d", MAXIMUM_EADID_LENGTH, config.MaximumEADIDLength)
+			}
+
+			validator, err := profile.NewValidator()
+			failOnError(t, err)
+
+			result, err := validator.ValidateFile(invalidEadDataFixturePath)
+			failOnError(t, err)
+
+			// the eadid rule is disabled and "NYU Archives" is a valid repository in this profile
+			if len(result.Issues) != 2 {
+				t.Fatalf("Expected 2 issues, got %d: %v", len(result.Issues), result.Issues)
+			}
+			for _, issue := range result.Issues {
+				assertEqual(t, CodeUnpublishedMaterial, issue.Code, "issue code")
+			}
+		})
+	}
+}
+
+func TestReadProfileEmptyProfileUsesDefaults(t *testing.T) {
+	profile, err := ReadProfileFromFile(getProfileFixturePath("empty.yaml"))
+	failOnError(t, err)
+
+	config := profile.Config()
+	if len(config.ValidRepositoryNames) != len(ValidRepositoryNames) {
+		t.Errorf("Expected %d repository names, got %d", len(ValidRepositoryNames), len(config.ValidRepositoryNames))
+	}
+
+	validator, err := profile.NewValidator()
+	failOnError(t, err)
+
+	if len(validator.Prerequisites) != len(DefaultPrerequisites()) || len(validator.Rules) != len(DefaultRules()) {
+		t.Errorf("Expected all default rules to be enabled")
+	}
+}
+
+func TestReadProfileExplicitEmptyRepositoryList(t *testing.T) {
+	profile, err := ReadProfile(strings.NewReader("valid_repository_names: []\n"))
+	failOnError(t, err)
+
+	if len(profile.Config().ValidRepositoryNames) != 0 {
+		t.Errorf("Expected an empty list of repository names, got %v", profile.Config().ValidRepositoryNames)
+	}
+}
+
+func TestReadProfileEADIDCharacterAnalysis(t *testing.T) {
+	testCases := []struct {
+		profile string
+		want    bool
+	}{
+		{"", true},
+		{"eadid_pattern: \"^[a-z]+$\"\n", false},
+		{"eadid_pattern: \"^[a-z]+$\"\neadid_character_analysis: true\n", true},
+		{"eadid_character_analysis: false\n", false},
+	}
+
+	for _, tc := range testCases {
+		profile, err := ReadProfile(strings.NewReader(tc.profile))
+		failOnError(t, err)
+
+		if got := profile.Config().EADIDCharacterAnalysis; got != tc.want {
+			t.Errorf("%q: expected EADIDCharacterAnalysis %t, got %t", tc.profile, tc.want, got)
+		}
+	}
+}
+
+func TestReadProfileErrors(t *testing.T) {
+	testCases := []struct {
+		filename string
+		want     string
+	}{
+		{"malformed.json", "malformed validation profile"},
+		{"unknown-setting.yaml", "field valid_repository_name not found"},
+		{"unknown-rule.yaml", `unknown rule(s) "no-such-rule"`},
+		{"invalid-eadid-pattern.yaml", `eadid_pattern "^[a-z" is not a valid regular expression`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.filename, func(t *testing.T) {
+			_, err := ReadProfileFromFile(getProfileFixturePath(tc.filename))
+			if err == nil {
+				t.Fatalf("Expected an error")
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Errorf(`Expected error containing "%s", got "%s"`, tc.want, err)
+			}
+			if !strings.Contains(err.Error(), tc.filename) {
+				t.Errorf(`Expected error to include the file name, got "%s"`, err)
+			}
+		})
+	}
+
+	_, err := ReadProfile(strings.NewReader("maximum_file_size: -1\n"))
+	if err == nil || !strings.Contains(err.Error(), "maximum_file_size must not be negative") {
+		t.Errorf("Expected a negative maximum_file_size error, got %v", err)
+	}
+
+	_, err = ReadProfile(strings.NewReader("valid_repository_names: [\" \"]\n"))
+	if err == nil || !strings.Contains(err.Error(), "valid_repository_names entry 1 is blank") {
+		t.Errorf("Expected a blank repository name error, got %v", err)
+	}
+}
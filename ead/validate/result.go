@@ -0,0 +1,102 @@
+package validate
+
+// Stable rule codes for ValidationIssue.Code.
+// These values are part of the package API: consumers may switch on them,
+// so do not change existing codes.
+const (
+	CodeInvalidXML             = "invalid-xml"
+	CodeParseError             = "parse-error"
+	CodeExportedWithPlugin     = "exported-with-plugin"
+	CodeSchemaUnavailable      = "schema-unavailable"
+	CodeSchemaInvalid          = "schema-invalid"
+	CodeSchemaViolation        = "schema-violation"
+	CodeMissingRequiredElement = "missing-required-element"
+	CodeInvalidEADID           = "invalid-eadid"
+	CodeEADIDTooLong           = "eadid-too-long"
+	CodeInvalidRepository      = "invalid-repository"
+	CodeInvalidArchDescLevel   = "invalid-archdesc-level"
+	CodeUnpublishedMaterial    = "unpublished-material"
+	CodeFileTooBig             = "file-too-big"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// ValidationIssue describes a single problem found in an EAD.
+//
+// XPath and Line/Column are populated when the check that produced the
+// issue is able to determine the location of the problem.  XPaths are fully
+// positional, e.g., "/ead[1]/archdesc[1]/did[1]/repository[1]/corpname[1]".
+// Value holds the offending value, e.g., the invalid <eadid> value.
+type ValidationIssue struct {
+	Code     string   `json:"code"`
+	Severity Severity `json:"severity"`
+	XPath    string   `json:"xpath,omitempty"`
+	Line     int      `json:"line,omitempty"`
+	Column   int      `json:"column,omitempty"`
+	Value    string   `json:"value,omitempty"`
+	Message  string   `json:"message"`
+}
+
+// ValidationResult collects the issues found while validating an EAD
+type ValidationResult struct {
+	Source string            `json:"source,omitempty"`
+	Issues []ValidationIssue `json:"issues"`
+}
+
+// Valid returns true if no error-level issues were found
+func (r *ValidationResult) Valid() bool {
+	for _, issue := range r.Issues {
+		if issue.Severity == SeverityError {
+			return false
+		}
+	}
+	return true
+}
+
+// Messages flattens the issues into the human-readable strings returned by
+// ValidateEAD() and ValidateEADFromFilePath().
+//
+// The output is identical to the pre-ValidationResult API:
+//   - all <... audience="internal"> issues are reported in a single message
+//   - schema issues are preceded by the generic invalid XML message
+//   - parse errors are followed by the underlying parser error
+func (r *ValidationResult) Messages() []string {
+	var messages = []string{}
+	var audienceInternalElements = []string{}
+
+	for _, issue := range r.Issues {
+		switch issue.Code {
+		case CodeUnpublishedMaterial:
+			audienceInternalElements = append(audienceInternalElements, issue.Value)
+		case CodeSchemaUnavailable, CodeSchemaInvalid:
+			messages = append(messages, makeInvalidXMLErrorMessage(), issue.Message)
+		case CodeParseError:
+			messages = append(messages, issue.Message, issue.Value)
+		default:
+			messages = append(messages, issue.Message)
+		}
+	}
+
+	if len(audienceInternalElements) > 0 {
+		messages = append(messages, makeAudienceInternalErrorMessage(audienceInternalElements))
+	}
+
+	return messages
+}
+
+func newValidationResult() *ValidationResult {
+	return &ValidationResult{Issues: []ValidationIssue{}}
+}
+
+func (r *ValidationResult) addIssues(issues ...ValidationIssue) {
+	r.Issues = append(r.Issues, issues...)
+}
+
+func newIssue(code string, message string) ValidationIssue {
+	return ValidationIssue{Code: code, Severity: SeverityError, Message: message}
+}
@@ -0,0 +1,83 @@
+package validate
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nyulibraries/dlts-finding-aids-ead-go-packages/ead"
+)
+
+func roundTripEAD(t *testing.T, EADXML []byte) (*ead.EAD, []byte) {
+	var e ead.EAD
+	err := xml.Unmarshal(EADXML, &e)
+	failOnError(t, err)
+
+	marshaledXML, err := xml.MarshalIndent(e, "", "  ")
+	failOnError(t, err)
+
+	return &e, append([]byte(xml.Header), marshaledXML...)
+}
+
+func TestRoundTripMarshaledEADIsValid(t *testing.T) {
+	for _, fixturePath := range []string{
+		validEADFixturePath,
+		akkasahRepositoryNameFixturePath,
+		cbhValidEADFixturePath,
+		bcValidEADFixturePath,
+		bhsValidEADFixturePath,
+		arabartarchiveValidEADFixturePath,
+		filepath.Join(fixturesDirPath, "rg_6_0.xml"),
+	} {
+		t.Run(filepath.Base(fixturePath), func(t *testing.T) {
+			original, marshaledXML := roundTripEAD(t, getEADXML(fixturePath))
+
+			errors, err := ValidateEAD(marshaledXML)
+			failOnError(t, err)
+			if len(errors) > 0 {
+				t.Errorf("Expected no validation errors for the marshaled EAD, got:\n%s", strings.Join(errors, "\n"))
+			}
+
+			// the marshaled EAD must unmarshal to the same data
+			roundTripped, _ := roundTripEAD(t, marshaledXML)
+
+			want, err := json.Marshal(original)
+			failOnError(t, err)
+			got, err := json.Marshal(roundTripped)
+			failOnError(t, err)
+			if string(want) != string(got) {
+				t.Errorf("Round-tripped EAD does not match the original EAD")
+			}
+		})
+	}
+}
+
+func TestRoundTripEditedEADIsValid(t *testing.T) {
+	var e ead.EAD
+	err := xml.Unmarshal(getEADXML(validEADFixturePath), &e)
+	failOnError(t, err)
+
+	e.EADHeader.EADID.Value = "mc_100_edited"
+	e.ArchDesc.DID.UnitTitle.Value = "Edited <emph render=\"italic\">title</emph> &amp; more"
+	e.ArchDesc.DSC.C = e.ArchDesc.DSC.C[:1]
+
+	marshaledXML, err := xml.Marshal(e)
+	failOnError(t, err)
+
+	errors, err := ValidateEAD(marshaledXML)
+	failOnError(t, err)
+	if len(errors) > 0 {
+		t.Errorf("Expected no validation errors for the edited EAD, got:\n%s", strings.Join(errors, "\n"))
+	}
+
+	var edited ead.EAD
+	err = xml.Unmarshal(marshaledXML, &edited)
+	failOnError(t, err)
+	assertEqual(t, "mc_100_edited", edited.EADID(), "Edited EADID")
+	assertEqual(t, e.ArchDesc.DID.UnitTitle.Value, edited.GuideTitle(), "Edited unit title")
+	if len(edited.ArchDesc.DSC.C) != 1 {
+		t.Errorf("Expected 1 top-level component, got %d", len(edited.ArchDesc.DSC.C))
+	}
+}
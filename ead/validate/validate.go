@@ -7,8 +7,11 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path"
+	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"unicode"
 
@@ -22,11 +25,20 @@ import (
 //go:embed schema
 var schemas embed.FS
 
+const schemaDir = "schema"
+const eadSchemaFile = "ead-2002-20210412-dlts.xsd"
+
 const ValidEADIDRegexpString = "^[a-z0-9]+(?:_[a-z0-9]+){1,}$"
 const MAXIMUM_EADID_LENGTH = 251
 const MAXIMUM_FILE_SIZE = 100_000_000 // 100 MB
 const ARCHDESC_REQUIRED_LEVEL = "collection"
 
+// Issue XPaths are fully positional, e.g., "/ead[1]/archdesc[1]/bioghist[2]"
+const eadXPath = "/ead[1]"
+const archDescXPath = eadXPath + "/archdesc[1]"
+const eadidXPath = eadXPath + "/eadheader[1]/eadid[1]"
+const repositoryXPath = archDescXPath + "/did[1]/repository[1]"
+
 var ValidRepositoryNames = []string{
 	"Akkasah: Photography Archive (NYU Abu Dhabi)",
 	"al Mawrid Arab Art Archive, NYU Abu Dhabi",
@@ -45,76 +57,24 @@ var ValidRepositoryNames = []string{
 // this function is required to perform file-level checks,
 // like maximum file size
 func ValidateEADFromFilePath(filepath string) ([]string, error) {
-	var validationErrors = []string{}
-
-	fileInfo, err := os.Stat(filepath)
-	if err != nil {
-		return validationErrors, err
-	}
-
-	if fileInfo.Size() > MAXIMUM_FILE_SIZE {
-		return append(validationErrors, makeFileTooBigErrorMessage(filepath, fileInfo.Size())), nil
-	}
-
-	EADXML, err := os.ReadFile(filepath)
-	if err != nil {
-		return validationErrors, err
-	}
-
-	return ValidateEAD(EADXML)
+	result, err := ValidateEADFromFilePathWithResult(filepath)
+	return result.Messages(), err
 }
 
 func ValidateEAD(data []byte) ([]string, error) {
-	var validationErrors = []string{}
-
-	// Performing a multi-stage validation.
-	// * assert that the EAD contains valid XML
-	// * assert that the EAD was not exported using the ASpace EAD plugin
-	// * assert that the EAD is valid per the EAD schema
-	// * assert that the EAD complies with FADESIGN-specific validation criteria
-	//
-	// This approach avoids unwanted libxml2 output when the data is not XML
-
-	validationErrors = append(validationErrors, validateXML(data)...)
-	// If the data is not valid XML there is no point doing any more checks.
-	if len(validationErrors) > 0 {
-		return validationErrors, nil
-	}
-
-	validationErrors = append(validationErrors, validateEADNotExportedWithPlugin(data)...)
-	// If the EAD was exported with the plugin enabled, there is no point doing any more checks.
-	if len(validationErrors) > 0 {
-		return validationErrors, nil
-	}
-
-	validationErrors = append(validationErrors, validateEADAgainstSchema(data)...)
-	// If the data is not a valid EAD there is no point doing any more checks.
-	if len(validationErrors) > 0 {
-		return validationErrors, nil
-	}
-
-	var ead ead.EAD
-	err := xml.Unmarshal(data, &ead)
-	if err != nil {
-		return validationErrors, err
-	}
-
-	validateEADIDValidationErrors, err := validateEADID(ead)
-	if err != nil {
-		return validationErrors, err
-	}
-	validationErrors = append(validationErrors, validateEADIDValidationErrors...)
-	validationErrors = append(validationErrors, validateRepository(ead)...)
-	validationErrors = append(validationErrors, validateArchDescLevel(ead)...)
-
-	validateNoUnpublishedMaterialValidationErrors, err := validateNoUnpublishedMaterial(data)
-	if err != nil {
-		return validationErrors, err
-	}
+	result, err := ValidateEADWithResult(data)
+	return result.Messages(), err
+}
 
-	validationErrors = append(validationErrors, validateNoUnpublishedMaterialValidationErrors...)
+// ValidateEADFromFilePathWithResult is the structured equivalent of
+// ValidateEADFromFilePath()
+func ValidateEADFromFilePathWithResult(filepath string) (*ValidationResult, error) {
+	return NewDefaultValidator().ValidateFile(filepath)
+}
 
-	return validationErrors, err
+// ValidateEADWithResult is the structured equivalent of ValidateEAD()
+func ValidateEADWithResult(data []byte) (*ValidationResult, error) {
+	return NewDefaultValidator().Validate(data)
 }
 
 func makeAudienceInternalErrorMessage(elementsAudienceInternal []string) string {
@@ -139,7 +99,7 @@ func invalidCharactersToString(invalidCharacters []rune) string {
 	return strings.Join(s, ", ")
 }
 
-func makeInvalidEADIDErrorMessage(eadid string, invalidCharacters []rune) string {
+func makeInvalidEADIDErrorMessage(eadid string, invalidCharacters []rune, maximumLength int) string {
 	return fmt.Sprintf(`Invalid <eadid>
 
 <eadid> value "%s" does not conform to the Finding Aids specification.
@@ -149,18 +109,25 @@ There is no maximum number of character groups, however, the <eadid>
 value must have at most %d characters.
 The following characters found in the eadid value are not allowed in
 character groups: %s
-`, eadid, MAXIMUM_EADID_LENGTH, invalidCharactersToString(invalidCharacters))
+`, eadid, maximumLength, invalidCharactersToString(invalidCharacters))
 }
 
-func makeEADIDTooLongErrorMessage(eadid string) string {
+func makeEADIDPatternMismatchErrorMessage(eadid string, pattern string) string {
+	return fmt.Sprintf(`Invalid <eadid>
+
+<eadid> value "%s" does not match the pattern "%s"
+`, eadid, pattern)
+}
+
+func makeEADIDTooLongErrorMessage(eadid string, maximumLength int) string {
 	return fmt.Sprintf(`<eadid> length too long
 
 	The <eadid> value in this EAD "%s" is %d characters.
 	This exceeds the maximum allowed length of %d characters.`,
-		eadid, len(eadid), MAXIMUM_EADID_LENGTH)
+		eadid, len(eadid), maximumLength)
 }
 
-func makeFileTooBigErrorMessage(filepath string, size int64) string {
+func makeFileTooBigErrorMessage(filepath string, size int64, maximumSize int64) string {
 	// https://pkg.go.dev/golang.org/x/text/message
 	p := message.NewPrinter(message.MatchLanguage("en"))
 
@@ -169,17 +136,23 @@ func makeFileTooBigErrorMessage(filepath string, size int64) string {
 	The size of the EAD file "%s"
 	is %d bytes. The maximum allowed file size 
 	is %d bytes.`,
-		filepath, size, MAXIMUM_FILE_SIZE)
+		filepath, size, maximumSize)
 }
 
-func makeInvalidRepositoryErrorMessage(repositoryName string) string {
+func makeFileTooBigIssue(filepath string, size int64, maximumSize int64) ValidationIssue {
+	issue := newIssue(CodeFileTooBig, makeFileTooBigErrorMessage(filepath, size, maximumSize))
+	issue.Value = strconv.FormatInt(size, 10)
+	return issue
+}
+
+func makeInvalidRepositoryErrorMessage(repositoryName string, validRepositoryNames []string) string {
 	return fmt.Sprintf(`Invalid <repository>
 
 <repository> contains unknown repository name "%s".
 The repository name must match a value from this list:
 
 %s
-`, repositoryName, strings.Join(ValidRepositoryNames, "\n"))
+`, repositoryName, strings.Join(validRepositoryNames, "\n"))
 }
 
 func makeInvalidXMLErrorMessage() string {
@@ -194,16 +167,16 @@ func makeMissingRequiredElementErrorMessage(elementName string) string {
 	return fmt.Sprintf("Required element %s not found.", elementName)
 }
 
-func makeInvalidArchDescLevelErrorMessage(level string) string {
+func makeInvalidArchDescLevelErrorMessage(level string, requiredLevel string) string {
 	return fmt.Sprintf(`Invalid <archdesc> level
 
 	The archdesc level attribute must be set to "%s".
 	This EAD's archdesc level attribute is set to "%s"`,
-		ARCHDESC_REQUIRED_LEVEL, level)
+		requiredLevel, level)
 }
 
-func validateEADID(ead ead.EAD) ([]string, error) {
-	var validationErrors = []string{}
+func validateEADID(ead ead.EAD, config *Config) ([]ValidationIssue, error) {
+	var issues = []ValidationIssue{}
 
 	var EADID = ead.EADHeader.EADID.Value
 
@@ -211,17 +184,24 @@ func validateEADID(ead ead.EAD) ([]string, error) {
 	// be not empty.  Test for empty string.
 	if EADID == "" || (len(strings.TrimSpace(EADID)) == 0) {
 		// empty EADID or EADID value is made up of blank space
-		validationErrors = append(validationErrors,
-			makeMissingRequiredElementErrorMessage("<eadid>"))
+		issue := newIssue(CodeMissingRequiredElement, makeMissingRequiredElementErrorMessage("<eadid>"))
+		issue.XPath = eadidXPath
+		issues = append(issues, issue)
 
 	} else {
 		// EADID is not empty
-		match, err := regexp.Match(ValidEADIDRegexpString, []byte(EADID))
+		match, err := regexp.Match(config.EADIDRegexpString, []byte(EADID))
 		if err != nil {
-			return validationErrors, err
+			return issues, err
 		}
 
-		if !match {
+		if !match && !config.EADIDCharacterAnalysis {
+			issue := newIssue(CodeInvalidEADID, makeEADIDPatternMismatchErrorMessage(EADID, config.EADIDRegexpString))
+			issue.XPath = eadidXPath
+			issue.Value = EADID
+			issues = append(issues, issue)
+		} else if !match {
+			// the invalid character analysis is specific to the FADESIGN <eadid> criteria
 			var invalidCharacters = []rune{}
 			charMap := make(map[rune]uint, len(EADID))
 			for _, r := range EADID {
@@ -232,96 +212,141 @@ func validateEADID(ead ead.EAD) ([]string, error) {
 					invalidCharacters = append(invalidCharacters, char)
 				}
 			}
-			validationErrors = append(validationErrors, makeInvalidEADIDErrorMessage(EADID, invalidCharacters))
+			issue := newIssue(CodeInvalidEADID, makeInvalidEADIDErrorMessage(EADID, invalidCharacters, config.MaximumEADIDLength))
+			issue.XPath = eadidXPath
+			issue.Value = EADID
+			issues = append(issues, issue)
 		}
 
-		if len(EADID) > MAXIMUM_EADID_LENGTH {
-			validationErrors = append(validationErrors, makeEADIDTooLongErrorMessage(EADID))
+		if config.MaximumEADIDLength > 0 && len(EADID) > config.MaximumEADIDLength {
+			issue := newIssue(CodeEADIDTooLong, makeEADIDTooLongErrorMessage(EADID, config.MaximumEADIDLength))
+			issue.XPath = eadidXPath
+			issue.Value = EADID
+			issues = append(issues, issue)
 		}
 	}
 
-	return validationErrors, nil
+	return issues, nil
 }
 
-func validateNoUnpublishedMaterial(data []byte) ([]string, error) {
-	var validationErrors = []string{}
+func validateNoUnpublishedMaterial(data []byte) ([]ValidationIssue, error) {
+	var issues = []ValidationIssue{}
 
 	decoder := xml.NewDecoder(bytes.NewReader(data))
 
-	audienceInternalElements := []string{}
+	// the element path and per-parent sibling counts are tracked
+	// so that each issue can be reported with an XPath
+	var path []string
+	var siblingCounts = []map[string]int{{}}
 	for {
+		offset := decoder.InputOffset()
 		token, err := decoder.Token()
 		if token == nil || err == io.EOF {
 			break
 		} else if err != nil {
-			return []string{}, err
+			return []ValidationIssue{}, err
 		}
 
 		switch tokenType := token.(type) {
 		case xml.StartElement:
 			var elementName = tokenType.Name.Local
+
+			counts := siblingCounts[len(siblingCounts)-1]
+			counts[elementName]++
+			path = append(path, fmt.Sprintf("%s[%d]", elementName, counts[elementName]))
+			siblingCounts = append(siblingCounts, map[string]int{})
+
 			for _, attribute := range tokenType.Attr {
 				attributeName := attribute.Name.Local
 				attributeValue := attribute.Value
 
 				if attributeName == "audience" && attributeValue == "internal" {
-					audienceInternalElements = append(audienceInternalElements,
-						fmt.Sprintf("<%s>", elementName))
+					element := fmt.Sprintf("<%s>", elementName)
+					issue := newIssue(CodeUnpublishedMaterial,
+						fmt.Sprintf(`Element %s has attribute audience="internal"`, element))
+					issue.XPath = "/" + strings.Join(path, "/")
+					issue.Line, issue.Column = lineAndColumn(data, offset)
+					issue.Value = element
+					issues = append(issues, issue)
 				}
 			}
+		case xml.EndElement:
+			path = path[:len(path)-1]
+			siblingCounts = siblingCounts[:len(siblingCounts)-1]
 		default:
 		}
 	}
 
-	if len(audienceInternalElements) > 0 {
-		validationErrors = append(validationErrors, makeAudienceInternalErrorMessage(audienceInternalElements))
-	}
-
-	return validationErrors, nil
+	return issues, nil
 }
 
-func validateRepository(ead ead.EAD) []string {
-	var validationErrors = []string{}
+func validateRepository(ead ead.EAD, config *Config) []ValidationIssue {
+	var issues = []ValidationIssue{}
 
 	if ead.ArchDesc != nil {
 		// If ead.ArchDesc exists, DID will be non-nil, so can move on to testing Repository.
 		if ead.ArchDesc.DID.Repository != nil {
 			if ead.ArchDesc.DID.Repository.CorpName == nil {
-				validationErrors = append(validationErrors,
+				issue := newIssue(CodeMissingRequiredElement,
 					makeMissingRequiredElementErrorMessage("<archdesc>/<did>/<repository>/<corpname>"))
+				issue.XPath = repositoryXPath + "/corpname[1]"
+				issues = append(issues, issue)
 			} else {
 				var repositoryName = ead.ArchDesc.DID.Repository.CorpName[0].Value
 
-				for _, validRepository := range ValidRepositoryNames {
+				// an empty list of valid repository names allows any repository name
+				if len(config.ValidRepositoryNames) == 0 {
+					return []ValidationIssue{}
+				}
+
+				for _, validRepository := range config.ValidRepositoryNames {
 					if repositoryName == validRepository {
-						return []string{}
+						return []ValidationIssue{}
 					}
 				}
 
-				validationErrors = append(validationErrors, makeInvalidRepositoryErrorMessage(repositoryName))
+				issue := newIssue(CodeInvalidRepository,
+					makeInvalidRepositoryErrorMessage(repositoryName, config.ValidRepositoryNames))
+				issue.XPath = repositoryXPath + "/corpname[1]"
+				issue.Value = repositoryName
+				issues = append(issues, issue)
 			}
 		} else {
-			validationErrors = append(validationErrors,
+			issue := newIssue(CodeMissingRequiredElement,
 				makeMissingRequiredElementErrorMessage("<archdesc>/<did>/<repository>"))
+			issue.XPath = repositoryXPath
+			issues = append(issues, issue)
 		}
 	} else {
-		validationErrors = append(validationErrors,
-			makeMissingRequiredElementErrorMessage("<archdesc>"))
+		issue := newIssue(CodeMissingRequiredElement, makeMissingRequiredElementErrorMessage("<archdesc>"))
+		issue.XPath = archDescXPath
+		issues = append(issues, issue)
 	}
 
-	return validationErrors
+	return issues
 }
 
-func validateArchDescLevel(ead ead.EAD) []string {
-	var validationErrors = []string{}
+func validateArchDescLevel(ead ead.EAD, config *Config) []ValidationIssue {
+	var issues = []ValidationIssue{}
 
 	if ead.ArchDesc != nil {
 		level := string(ead.ArchDesc.Level)
-		if level != ARCHDESC_REQUIRED_LEVEL {
-			return append(validationErrors, makeInvalidArchDescLevelErrorMessage(level))
+		if config.ArchDescRequiredLevel != "" && level != config.ArchDescRequiredLevel {
+			issue := newIssue(CodeInvalidArchDescLevel, makeInvalidArchDescLevelErrorMessage(level, config.ArchDescRequiredLevel))
+			issue.XPath = archDescXPath + "/@level"
+			issue.Value = level
+			return append(issues, issue)
 		}
 	}
-	return validationErrors
+	return issues
+}
+
+// lineAndColumn converts a byte offset into 1-based line and column numbers
+func lineAndColumn(data []byte, offset int64) (int, int) {
+	preceding := data[:offset]
+	line := bytes.Count(preceding, []byte("\n")) + 1
+	column := len(preceding) - bytes.LastIndexByte(preceding, '\n')
+	return line, column
 }
 
 // The following comment and function validateXML() are from David Arjanik:
@@ -354,80 +379,116 @@ func validateArchDescLevel(ead ead.EAD) []string {
 // I did a quick search for some 3rd party libraries for validating against a schema,
 // which would allow for validation against https://www.loc.gov/ead/eadschema.html, but
 // I have some reservations about using them -- see https://jira.nyu.edu/browse/FADESIGN-491.
-func validateXML(data []byte) []string {
-	var validationErrors = []string{}
+func validateXML(data []byte) []ValidationIssue {
+	var issues = []ValidationIssue{}
 
 	// Not perfect, but maybe good enough for now.
 	if xml.Unmarshal(data, new(interface{})) != nil {
-		validationErrors = append(validationErrors, makeInvalidXMLErrorMessage())
+		issues = append(issues, newIssue(CodeInvalidXML, makeInvalidXMLErrorMessage()))
 	}
 
-	return validationErrors
+	return issues
 }
 
 // This function is largely borrowed from Don Mennerich's go-aspace package
 // https://github.com/nyudlts/go-aspace
-func validateEADAgainstSchema(data []byte) []string {
-	var validationErrors = []string{}
-
-	// initialize with a default error message
-	validationErrors = append(validationErrors, makeInvalidXMLErrorMessage())
-
-	schema, err := schemas.ReadFile("schema/ead-2002-20210412-dlts.xsd")
-	if err != nil {
-		return append(validationErrors, err.Error())
-	}
-
-	eadxsd, err := xsd.Parse(schema)
+func validateEADAgainstSchema(data []byte) []ValidationIssue {
+	eadxsd, err := parseEADSchema()
 	if err != nil {
-		return append(validationErrors, err.Error())
+		return []ValidationIssue{newIssue(CodeSchemaUnavailable, err.Error())}
 	}
 	defer eadxsd.Free()
 
+	return validateEADAgainstParsedSchema(eadxsd, data)
+}
+
+// validateEADAgainstParsedSchema validates the EAD against an already-parsed schema.
+// Each call uses its own libxml2 validation context, so a single parsed
+// schema can be shared by concurrent callers.
+func validateEADAgainstParsedSchema(eadxsd *xsd.Schema, data []byte) []ValidationIssue {
+	var issues = []ValidationIssue{}
+
 	p := parser.New()
 	doc, err := p.Parse(data)
 	if err != nil {
-		validationErrors = append(validationErrors, "Unable to parse XML file")
-		return append(validationErrors, err.Error())
+		return append(issues, makeParseErrorIssue(err))
 	}
 	defer doc.Free()
 
 	err = eadxsd.Validate(doc)
 	if err != nil {
 		// capture the high-level error message
-		validationErrors = append(validationErrors, err.Error())
+		issues = append(issues, newIssue(CodeSchemaInvalid, err.Error()))
 
 		// capture the detailed error info:
 		// the Validate function returns an xsd.SchemaValidationError that
 		// has an underlying Errors() method with more detailed errors
 		for _, e := range err.(xsd.SchemaValidationError).Errors() {
-			validationErrors = append(validationErrors, e.Error())
+			issue := newIssue(CodeSchemaViolation, e.Error())
+			issue.Value = schemaErrorElementName(e.Error())
+			issues = append(issues, issue)
 		}
-		return validationErrors
+		return issues
 	}
 
 	// all ok, return empty slice
-	return []string{}
+	return []ValidationIssue{}
+}
+
+// libxml2 performs the schema validation and does not have access to the
+// embedded FS, so the <xs:import> of xlink.xsd (which in turn imports xml.xsd)
+// cannot be resolved from memory.  Instead, the embedded schema files are
+// written to a temporary directory, and the EAD schema is parsed from there so
+// that the relative schemaLocation values resolve to the bundled files.
+// No network access is required.
+//
+// The temporary directory is removed once parsing is complete because
+// the parsed schema is held in memory.
+func parseEADSchema() (*xsd.Schema, error) {
+	dir, err := os.MkdirTemp("", "ead-schema-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	entries, err := schemas.ReadDir(schemaDir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		data, err := schemas.ReadFile(path.Join(schemaDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		err = os.WriteFile(filepath.Join(dir, entry.Name()), data, 0600)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return xsd.ParseFromFile(filepath.Join(dir, eadSchemaFile))
 }
 
 // Validate that the EAD was NOT exported using the ASpace EAD plugin
 // https://guides.nyu.edu/archivesspace/development
 // https://github.com/NYULibraries/nyu_ead_export_plugin
-func validateEADNotExportedWithPlugin(data []byte) []string {
-	var validationErrors = []string{}
+func validateEADNotExportedWithPlugin(data []byte) []ValidationIssue {
+	var issues = []ValidationIssue{}
 
 	p := parser.New()
 	doc, err := p.Parse(data)
 	if err != nil {
-		validationErrors = append(validationErrors, "Unable to parse XML file")
-		return append(validationErrors, err.Error())
+		return append(issues, makeParseErrorIssue(err))
 	}
 	defer doc.Free()
 
 	root, err := doc.DocumentElement()
 	if err != nil {
-		validationErrors = append(validationErrors, "Failed to fetch document root element")
-		return append(validationErrors, err.Error())
+		issue := newIssue(CodeParseError, "Failed to fetch document root element")
+		issue.Value = err.Error()
+		return append(issues, issue)
 	}
 
 	// check if ns2 namespace is defined.
@@ -436,10 +497,28 @@ func validateEADNotExportedWithPlugin(data []byte) []string {
 	_, err = root.LookupNamespaceURI("ns2")
 	if err == nil {
 		// ns2 namespace was found
-		validationErrors = append(validationErrors, makeExportedWithEADPluginErrorMessage())
-		return validationErrors
+		issue := newIssue(CodeExportedWithPlugin, makeExportedWithEADPluginErrorMessage())
+		issue.XPath = eadXPath
+		return append(issues, issue)
 	}
 
 	// all ok, return empty slice
-	return []string{}
+	return []ValidationIssue{}
+}
+
+func makeParseErrorIssue(err error) ValidationIssue {
+	issue := newIssue(CodeParseError, "Unable to parse XML file")
+	issue.Value = err.Error()
+	return issue
+}
+
+// libxml2 schema errors start with "Element '{namespace}name'..."
+var schemaErrorElementRegexp = regexp.MustCompile(`^Element '(?:\{[^}]*\})?([^']+)'`)
+
+func schemaErrorElementName(message string) string {
+	match := schemaErrorElementRegexp.FindStringSubmatch(message)
+	if match == nil {
+		return ""
+	}
+	return match[1]
 }
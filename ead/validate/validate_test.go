@@ -1,7 +1,11 @@
 package validate
 
 import (
+	"bytes"
+	"encoding/xml"
 	"fmt"
+	"io"
+	"net/url"
 	"os"
 	"path"
 	"path/filepath"
@@ -222,8 +226,8 @@ func TestValidEADIDRegexpString(t *testing.T) {
 
 func TestValidateEADInvalidData(t *testing.T) {
 	var expected = []string{
-		makeInvalidEADIDErrorMessage("mc.100", []rune{'.'}),
-		makeInvalidRepositoryErrorMessage("NYU Archives"),
+		makeInvalidEADIDErrorMessage("mc.100", []rune{'.'}, MAXIMUM_EADID_LENGTH),
+		makeInvalidRepositoryErrorMessage("NYU Archives", ValidRepositoryNames),
 		makeAudienceInternalErrorMessage([]string{"<bioghist>", "<processinfo>"}),
 	}
 
@@ -326,14 +330,14 @@ func TestValidateEADMissingRequiredElements(t *testing.T) {
 
 func TestValidateEADIDTooLong(t *testing.T) {
 	expected := []string{
-		makeEADIDTooLongErrorMessage("iiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiii_iiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiii"),
+		makeEADIDTooLongErrorMessage("iiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiii_iiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiiii", MAXIMUM_EADID_LENGTH),
 	}
 
 	doTest(eadIDTooLongFixturePath, expected, t)
 }
 func TestValidateArchDescLevel(t *testing.T) {
 	expected := []string{
-		makeInvalidArchDescLevelErrorMessage("series"),
+		makeInvalidArchDescLevelErrorMessage("series", ARCHDESC_REQUIRED_LEVEL),
 	}
 
 	doTest(invalidArchDescLevelFixturePath, expected, t)
@@ -360,7 +364,7 @@ func TestAssertMaxFileSize(t *testing.T) {
 	defer os.Remove(tooBigFileFixturePath)
 
 	expected := []string{
-		makeFileTooBigErrorMessage(tooBigFileFixturePath, MAXIMUM_FILE_SIZE+1),
+		makeFileTooBigErrorMessage(tooBigFileFixturePath, MAXIMUM_FILE_SIZE+1, MAXIMUM_FILE_SIZE),
 	}
 	doTestWithValidateEADFromFilePath(tooBigFileFixturePath, expected, t)
 }
@@ -379,7 +383,7 @@ func TestValidateEADValidCBHBrooklynHistoricalSociety(t *testing.T) {
 
 func TestValidateEADIDWithTrailingSpace(t *testing.T) {
 	expected := []string{
-		makeInvalidEADIDErrorMessage("mc_100 ", []rune{' '}),
+		makeInvalidEADIDErrorMessage("mc_100 ", []rune{' '}, MAXIMUM_EADID_LENGTH),
 	}
 
 	doTest(invalidEADTrailingSpaceInEADIDFixturePath, expected, t)
@@ -388,7 +392,7 @@ func TestValidateEADIDWithTrailingSpace(t *testing.T) {
 func TestValidateEADIDWithLeadingAndTrailingSpace(t *testing.T) {
 	invalidRunes := []rune("\n ")
 	expected := []string{
-		makeInvalidEADIDErrorMessage(" mc_100\n ", invalidRunes),
+		makeInvalidEADIDErrorMessage(" mc_100\n ", invalidRunes, MAXIMUM_EADID_LENGTH),
 	}
 
 	doTest(invalidEADWithEADIDLeadingAndTrailingWhitespaceFixturePath, expected, t)
@@ -396,7 +400,7 @@ func TestValidateEADIDWithLeadingAndTrailingSpace(t *testing.T) {
 
 func TestValidateEADIDWithLeadingSpace(t *testing.T) {
 	expected := []string{
-		makeInvalidEADIDErrorMessage(" mc_100", []rune{' '}),
+		makeInvalidEADIDErrorMessage(" mc_100", []rune{' '}, MAXIMUM_EADID_LENGTH),
 	}
 
 	doTest(invalidEADLeadingSpaceInEADIDFixturePath, expected, t)
@@ -417,3 +421,184 @@ func TestValidateEADExportedWithASpacePlugin(t *testing.T) {
 func TestValidateEADValidArabArtArchivee(t *testing.T) {
         doTest(arabartarchiveValidEADFixturePath, []string{}, t)
 }
+
+func assertIssue(t *testing.T, want ValidationIssue, got ValidationIssue) {
+	if want.Code != got.Code {
+		t.Errorf(`Expected issue code "%s", got "%s"`, want.Code, got.Code)
+	}
+	if want.Severity != got.Severity {
+		t.Errorf(`Expected issue severity "%s", got "%s"`, want.Severity, got.Severity)
+	}
+	if want.XPath != got.XPath {
+		t.Errorf(`Expected issue XPath "%s", got "%s"`, want.XPath, got.XPath)
+	}
+	if want.Line != got.Line || want.Column != got.Column {
+		t.Errorf(`Expected issue location %d:%d, got %d:%d`, want.Line, want.Column, got.Line, got.Column)
+	}
+	if want.Value != got.Value {
+		t.Errorf(`Expected issue value "%s", got "%s"`, want.Value, got.Value)
+	}
+}
+
+func TestValidateEADWithResultInvalidData(t *testing.T) {
+	result, err := ValidateEADWithResult(getEADXML(invalidEadDataFixturePath))
+	if err != nil {
+		t.Fatalf(`Unexpected runtime error: %s`, err)
+	}
+
+	expected := []ValidationIssue{
+		{Code: CodeInvalidEADID, Severity: SeverityError, XPath: "/ead[1]/eadheader[1]/eadid[1]", Value: "mc.100"},
+		{Code: CodeInvalidRepository, Severity: SeverityError, XPath: "/ead[1]/archdesc[1]/did[1]/repository[1]/corpname[1]", Value: "NYU Archives"},
+		{Code: CodeUnpublishedMaterial, Severity: SeverityError, XPath: "/ead[1]/archdesc[1]/bioghist[1]", Line: 36, Column: 3, Value: "<bioghist>"},
+		{Code: CodeUnpublishedMaterial, Severity: SeverityError, XPath: "/ead[1]/archdesc[1]/processinfo[1]", Line: 48, Column: 3, Value: "<processinfo>"},
+	}
+
+	if len(result.Issues) != len(expected) {
+		t.Fatalf("Expected %d issue(s), got %d: %v", len(expected), len(result.Issues), result.Issues)
+	}
+	for idx := range expected {
+		assertIssue(t, expected[idx], result.Issues[idx])
+	}
+
+	if result.Valid() {
+		t.Errorf("Expected result to be invalid")
+	}
+}
+
+func TestValidateEADWithResultInvalidXML(t *testing.T) {
+	result, err := ValidateEADWithResult(getEADXML(invalidXMLFixturePath))
+	if err != nil {
+		t.Fatalf(`Unexpected runtime error: %s`, err)
+	}
+
+	if len(result.Issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d: %v", len(result.Issues), result.Issues)
+	}
+	assertIssue(t, ValidationIssue{Code: CodeInvalidXML, Severity: SeverityError}, result.Issues[0])
+}
+
+func TestValidateEADWithResultSchemaViolations(t *testing.T) {
+	result, err := ValidateEADWithResult(getEADXML(invalidEADWithNamespaceErrorsFixturePath))
+	if err != nil {
+		t.Fatalf(`Unexpected runtime error: %s`, err)
+	}
+
+	if len(result.Issues) != 12 {
+		t.Fatalf("Expected 12 issues, got %d: %v", len(result.Issues), result.Issues)
+	}
+	assertIssue(t, ValidationIssue{Code: CodeSchemaInvalid, Severity: SeverityError}, result.Issues[0])
+	for _, issue := range result.Issues[1:] {
+		assertIssue(t, ValidationIssue{Code: CodeSchemaViolation, Severity: SeverityError, Value: "extref"}, issue)
+	}
+}
+
+func TestValidateEADFromFilePathWithResultValidEAD(t *testing.T) {
+	result, err := ValidateEADFromFilePathWithResult(validEADFixturePath)
+	if err != nil {
+		t.Fatalf(`Unexpected runtime error: %s`, err)
+	}
+
+	if !result.Valid() {
+		t.Errorf("Expected result to be valid, got issues: %v", result.Issues)
+	}
+	assertEqual(t, validEADFixturePath, result.Source, "ValidationResult.Source")
+}
+
+func TestValidationResultMessages(t *testing.T) {
+	result := newValidationResult()
+	result.addIssues(
+		ValidationIssue{Code: CodeInvalidEADID, Severity: SeverityError, Message: "eadid message"},
+		ValidationIssue{Code: CodeUnpublishedMaterial, Severity: SeverityError, Value: "<bioghist>", Message: "bioghist message"},
+		ValidationIssue{Code: CodeUnpublishedMaterial, Severity: SeverityError, Value: "<odd>", Message: "odd message"},
+		ValidationIssue{Code: CodeSchemaInvalid, Severity: SeverityError, Message: "schema validation failed"},
+		ValidationIssue{Code: CodeParseError, Severity: SeverityError, Value: "parser error", Message: "Unable to parse XML file"},
+	)
+
+	expected := []string{
+		"eadid message",
+		makeInvalidXMLErrorMessage(),
+		"schema validation failed",
+		"Unable to parse XML file",
+		"parser error",
+		makeAudienceInternalErrorMessage([]string{"<bioghist>", "<odd>"}),
+	}
+
+	got := result.Messages()
+	if len(got) != len(expected) {
+		t.Fatalf(getNumErrorsMismatchErrorMessage(expected, got))
+	}
+	for idx := range expected {
+		assertEqual(t, expected[idx], got[idx], fmt.Sprintf("message %d", idx))
+	}
+}
+
+func assertEqual(t *testing.T, want string, got string, label string) {
+	if want != got {
+		t.Errorf("%s Mismatch: want: %s, got: %s", label, want, got)
+	}
+}
+
+// TestEmbeddedSchemaImportsAreBundled checks that parsing the EAD schema can
+// not fetch anything over the network: every <xs:import>, <xs:include>, and
+// <xs:redefine> of the embedded schemas refers to a bundled file by a
+// relative schemaLocation
+func TestEmbeddedSchemaImportsAreBundled(t *testing.T) {
+	entries, err := schemas.ReadDir(schemaDir)
+	if err != nil {
+		t.Fatalf("Unexpected error reading the embedded schemas: %s", err)
+	}
+
+	bundled := map[string]bool{}
+	for _, entry := range entries {
+		bundled[entry.Name()] = true
+	}
+
+	var locations []string
+	for _, entry := range entries {
+		data, err := schemas.ReadFile(path.Join(schemaDir, entry.Name()))
+		if err != nil {
+			t.Fatalf("Unexpected error reading %s: %s", entry.Name(), err)
+		}
+
+		d := xml.NewDecoder(bytes.NewReader(data))
+		for {
+			token, err := d.Token()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("Unexpected error parsing %s: %s", entry.Name(), err)
+			}
+
+			start, ok := token.(xml.StartElement)
+			if !ok || start.Name.Space != "http://www.w3.org/2001/XMLSchema" {
+				continue
+			}
+			switch start.Name.Local {
+			case "import", "include", "redefine":
+			default:
+				continue
+			}
+
+			var location string
+			for _, attr := range start.Attr {
+				if attr.Name.Local == "schemaLocation" {
+					location = attr.Value
+				}
+			}
+			locations = append(locations, entry.Name()+" -> "+location)
+
+			u, err := url.Parse(location)
+			if err != nil || u.Scheme != "" || u.Host != "" || !bundled[u.Path] {
+				t.Errorf("%s <%s> schemaLocation %q is not a bundled schema", entry.Name(), start.Name.Local, location)
+			}
+		}
+	}
+
+	assertEqual(t, "ead-2002-20210412-dlts.xsd -> xlink.xsd\nxlink.xsd -> xml.xsd", strings.Join(locations, "\n"), "Schema imports")
+
+	eadxsd, err := parseEADSchema()
+	if err != nil {
+		t.Fatalf("Unexpected error parsing the EAD schema: %s", err)
+	}
+	eadxsd.Free()
+}
@@ -0,0 +1,273 @@
+package validate
+
+import (
+	"encoding/xml"
+	"os"
+
+	"github.com/lestrrat-go/libxml2/xsd"
+	"github.com/nyulibraries/dlts-finding-aids-ead-go-packages/ead"
+)
+
+// Names of the rules registered in the default profile
+const (
+	RuleXML                   = "xml"
+	RuleNotExportedWithPlugin = "not-exported-with-plugin"
+	RuleSchema                = "schema"
+	RuleEADID                 = "eadid"
+	RuleRepository            = "repository"
+	RuleArchDescLevel         = "archdesc-level"
+	RuleNoUnpublishedMaterial = "no-unpublished-material"
+)
+
+// Config holds the institution-specific validation criteria used by the rules
+//
+// A zero MaximumEADIDLength or MaximumFileSize, or an empty
+// ArchDescRequiredLevel, disables the corresponding check.
+// An empty ValidRepositoryNames slice allows any repository name, but the
+// <repository><corpname> element is still required.
+type Config struct {
+	ValidRepositoryNames []string
+	EADIDRegexpString    string
+
+	// EADIDCharacterAnalysis reports an <eadid> that does not match the
+	// EADIDRegexpString with the FADESIGN <eadid> criteria and the characters
+	// that are not allowed in character groups, instead of with the pattern.
+	// It only applies to patterns that implement the FADESIGN criteria.
+	EADIDCharacterAnalysis bool
+
+	MaximumEADIDLength    int
+	MaximumFileSize       int64
+	ArchDescRequiredLevel string
+}
+
+// DefaultConfig returns the FADESIGN validation criteria
+func DefaultConfig() Config {
+	return Config{
+		ValidRepositoryNames:   append([]string{}, ValidRepositoryNames...),
+		EADIDRegexpString:      ValidEADIDRegexpString,
+		EADIDCharacterAnalysis: true,
+		MaximumEADIDLength:     MAXIMUM_EADID_LENGTH,
+		MaximumFileSize:        MAXIMUM_FILE_SIZE,
+		ArchDescRequiredLevel:  ARCHDESC_REQUIRED_LEVEL,
+	}
+}
+
+// Document is the input to a Rule.
+// The EAD is only unmarshaled the first time that a rule asks for it.
+type Document struct {
+	Data []byte
+
+	ead       *ead.EAD
+	eadErr    error
+	unmarshal bool
+
+	// schema is set when the Validator has already parsed the EAD schema
+	schema *xsd.Schema
+}
+
+func NewDocument(data []byte) *Document {
+	return &Document{Data: data}
+}
+
+// EAD returns the unmarshaled EAD
+func (d *Document) EAD() (*ead.EAD, error) {
+	if !d.unmarshal {
+		d.unmarshal = true
+		d.ead = new(ead.EAD)
+		d.eadErr = xml.Unmarshal(d.Data, d.ead)
+	}
+	return d.ead, d.eadErr
+}
+
+// CheckFunc performs a single validation check.
+// The returned error is reserved for runtime errors, e.g., an invalid
+// regular expression, and not for validation failures.
+type CheckFunc func(doc *Document, config *Config) ([]ValidationIssue, error)
+
+// Rule is a single, named validation criterion
+type Rule interface {
+	Name() string
+	Check(doc *Document, config *Config) ([]ValidationIssue, error)
+}
+
+type rule struct {
+	name  string
+	check CheckFunc
+}
+
+// NewRule creates a Rule from a CheckFunc
+func NewRule(name string, check CheckFunc) Rule {
+	return &rule{name: name, check: check}
+}
+
+func (r *rule) Name() string {
+	return r.name
+}
+
+func (r *rule) Check(doc *Document, config *Config) ([]ValidationIssue, error) {
+	return r.check(doc, config)
+}
+
+// DefaultPrerequisites returns the rules that must pass before there is
+// any point in running the remaining rules:
+//   - the EAD must contain valid XML
+//   - the EAD must not have been exported using the ASpace EAD plugin
+//   - the EAD must be valid per the EAD schema
+func DefaultPrerequisites() []Rule {
+	return []Rule{
+		NewRule(RuleXML, func(doc *Document, config *Config) ([]ValidationIssue, error) {
+			return validateXML(doc.Data), nil
+		}),
+		NewRule(RuleNotExportedWithPlugin, func(doc *Document, config *Config) ([]ValidationIssue, error) {
+			return validateEADNotExportedWithPlugin(doc.Data), nil
+		}),
+		NewRule(RuleSchema, func(doc *Document, config *Config) ([]ValidationIssue, error) {
+			if doc.schema != nil {
+				return validateEADAgainstParsedSchema(doc.schema, doc.Data), nil
+			}
+			return validateEADAgainstSchema(doc.Data), nil
+		}),
+	}
+}
+
+// DefaultRules returns the FADESIGN-specific validation rules
+func DefaultRules() []Rule {
+	return []Rule{
+		NewRule(RuleEADID, func(doc *Document, config *Config) ([]ValidationIssue, error) {
+			ead, err := doc.EAD()
+			if err != nil {
+				return nil, err
+			}
+			return validateEADID(*ead, config)
+		}),
+		NewRule(RuleRepository, func(doc *Document, config *Config) ([]ValidationIssue, error) {
+			ead, err := doc.EAD()
+			if err != nil {
+				return nil, err
+			}
+			return validateRepository(*ead, config), nil
+		}),
+		NewRule(RuleArchDescLevel, func(doc *Document, config *Config) ([]ValidationIssue, error) {
+			ead, err := doc.EAD()
+			if err != nil {
+				return nil, err
+			}
+			return validateArchDescLevel(*ead, config), nil
+		}),
+		NewRule(RuleNoUnpublishedMaterial, func(doc *Document, config *Config) ([]ValidationIssue, error) {
+			return validateNoUnpublishedMaterial(doc.Data)
+		}),
+	}
+}
+
+// Validator runs a validation profile: a list of rules plus the Config
+// that parameterizes them.
+//
+// Prerequisites are run in order.  If a prerequisite reports any issues,
+// validation stops.  Otherwise, all Rules are run in order.
+type Validator struct {
+	Config        Config
+	Prerequisites []Rule
+	Rules         []Rule
+
+	// schema is only set on the Validator copies used by ValidateBatch()
+	schema *xsd.Schema
+}
+
+// NewValidator returns a Validator that uses the default rules with the given Config
+func NewValidator(config Config) *Validator {
+	return &Validator{
+		Config:        config,
+		Prerequisites: DefaultPrerequisites(),
+		Rules:         DefaultRules(),
+	}
+}
+
+// NewDefaultValidator returns a Validator for the FADESIGN validation criteria
+func NewDefaultValidator() *Validator {
+	return NewValidator(DefaultConfig())
+}
+
+// AddRule appends rules to the Validator
+func (v *Validator) AddRule(rules ...Rule) {
+	v.Rules = append(v.Rules, rules...)
+}
+
+// RemoveRule removes the named rule from the prerequisites and rules.
+// It returns false if no rule with that name is registered.
+func (v *Validator) RemoveRule(name string) bool {
+	var found bool
+	v.Prerequisites, found = removeRule(v.Prerequisites, name)
+	if found {
+		return true
+	}
+	v.Rules, found = removeRule(v.Rules, name)
+	return found
+}
+
+func removeRule(rules []Rule, name string) ([]Rule, bool) {
+	for i, r := range rules {
+		if r.Name() == name {
+			return append(rules[:i:i], rules[i+1:]...), true
+		}
+	}
+	return rules, false
+}
+
+// Validate validates EAD data
+func (v *Validator) Validate(data []byte) (*ValidationResult, error) {
+	var result = newValidationResult()
+
+	err := v.validate(NewDocument(data), result)
+	return result, err
+}
+
+// ValidateFile validates an EAD file. This function is required to
+// perform file-level checks, like maximum file size
+func (v *Validator) ValidateFile(filepath string) (*ValidationResult, error) {
+	var result = newValidationResult()
+	result.Source = filepath
+
+	fileInfo, err := os.Stat(filepath)
+	if err != nil {
+		return result, err
+	}
+
+	if v.Config.MaximumFileSize > 0 && fileInfo.Size() > v.Config.MaximumFileSize {
+		result.addIssues(makeFileTooBigIssue(filepath, fileInfo.Size(), v.Config.MaximumFileSize))
+		return result, nil
+	}
+
+	EADXML, err := os.ReadFile(filepath)
+	if err != nil {
+		return result, err
+	}
+
+	err = v.validate(NewDocument(EADXML), result)
+	return result, err
+}
+
+func (v *Validator) validate(doc *Document, result *ValidationResult) error {
+	doc.schema = v.schema
+
+	for _, r := range v.Prerequisites {
+		issues, err := r.Check(doc, &v.Config)
+		if err != nil {
+			return err
+		}
+		result.addIssues(issues...)
+		if len(issues) > 0 {
+			return nil
+		}
+	}
+
+	for _, r := range v.Rules {
+		issues, err := r.Check(doc, &v.Config)
+		if err != nil {
+			return err
+		}
+		result.addIssues(issues...)
+	}
+
+	return nil
+}
@@ -0,0 +1,229 @@
+package validate
+
+import (
+	"os"
+	"testing"
+
+	"github.com/nyulibraries/dlts-finding-aids-ead-go-packages/ead"
+)
+
+func getRule(t *testing.T, rules []Rule, name string) Rule {
+	for _, r := range rules {
+		if r.Name() == name {
+			return r
+		}
+	}
+	t.Fatalf(`Rule "%s" not found`, name)
+	return nil
+}
+
+func makeTestDocument(eadid string, repositoryName string, level string) *Document {
+	var e ead.EAD
+	e.EADHeader.EADID.Value = eadid
+	e.ArchDesc = &ead.ArchDesc{Level: ead.FilteredString(level)}
+	e.ArchDesc.DID.Repository = &ead.Repository{
+		CorpName: []*ead.AccessTermWithRole{{Value: repositoryName}},
+	}
+
+	doc := NewDocument(nil)
+	doc.ead = &e
+	doc.unmarshal = true
+	return doc
+}
+
+func TestDefaultConfig(t *testing.T) {
+	config := DefaultConfig()
+
+	assertEqual(t, ValidEADIDRegexpString, config.EADIDRegexpString, "EADIDRegexpString")
+	assertEqual(t, ARCHDESC_REQUIRED_LEVEL, config.ArchDescRequiredLevel, "ArchDescRequiredLevel")
+	if config.MaximumEADIDLength != MAXIMUM_EADID_LENGTH {
+		t.Errorf("Expected MaximumEADIDLength %d, got %d", MAXIMUM_EADID_LENGTH, config.MaximumEADIDLength)
+	}
+	if config.MaximumFileSize != MAXIMUM_FILE_SIZE {
+		t.Errorf("Expected MaximumFileSize %d, got %d", MAXIMUM_FILE_SIZE, config.MaximumFileSize)
+	}
+	if len(config.ValidRepositoryNames) != len(ValidRepositoryNames) {
+		t.Errorf("Expected %d repository names, got %d", len(ValidRepositoryNames), len(config.ValidRepositoryNames))
+	}
+}
+
+func TestRuleEADIDInIsolation(t *testing.T) {
+	rule := getRule(t, DefaultRules(), RuleEADID)
+	config := DefaultConfig()
+
+	issues, err := rule.Check(makeTestDocument("mc_100", "", ""), &config)
+	failOnError(t, err)
+	if len(issues) != 0 {
+		t.Errorf("Expected no issues, got %v", issues)
+	}
+
+	issues, err = rule.Check(makeTestDocument("MC-100", "", ""), &config)
+	failOnError(t, err)
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %v", issues)
+	}
+	assertIssue(t, ValidationIssue{Code: CodeInvalidEADID, Severity: SeverityError, XPath: eadidXPath, Value: "MC-100"}, issues[0])
+
+	config.EADIDRegexpString = "^[A-Z]+-[0-9]+$"
+	config.MaximumEADIDLength = 5
+	issues, err = rule.Check(makeTestDocument("MC-100", "", ""), &config)
+	failOnError(t, err)
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %v", issues)
+	}
+	assertIssue(t, ValidationIssue{Code: CodeEADIDTooLong, Severity: SeverityError, XPath: eadidXPath, Value: "MC-100"}, issues[0])
+	assertEqual(t, makeEADIDTooLongErrorMessage("MC-100", 5), issues[0].Message, "EADID too long message")
+
+	config.EADIDRegexpString = "^[a-z]+$"
+	config.EADIDCharacterAnalysis = false
+	config.MaximumEADIDLength = 0
+	issues, err = rule.Check(makeTestDocument("MC-100", "", ""), &config)
+	failOnError(t, err)
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %v", issues)
+	}
+	assertEqual(t, makeEADIDPatternMismatchErrorMessage("MC-100", "^[a-z]+$"), issues[0].Message, "EADID pattern mismatch message")
+
+	config.EADIDRegexpString = ValidEADIDRegexpString
+	issues, err = rule.Check(makeTestDocument("MC-100", "", ""), &config)
+	failOnError(t, err)
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %v", issues)
+	}
+	assertEqual(t, makeEADIDPatternMismatchErrorMessage("MC-100", ValidEADIDRegexpString), issues[0].Message, "EADID pattern mismatch message without character analysis")
+
+	config.EADIDRegexpString = "("
+	_, err = rule.Check(makeTestDocument("MC-100", "", ""), &config)
+	if err == nil {
+		t.Errorf("Expected an error for an invalid EADID regexp")
+	}
+}
+
+func TestRuleRepositoryInIsolation(t *testing.T) {
+	rule := getRule(t, DefaultRules(), RuleRepository)
+	config := DefaultConfig()
+
+	issues, err := rule.Check(makeTestDocument("", "Partner Archives", ""), &config)
+	failOnError(t, err)
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %v", issues)
+	}
+	assertIssue(t, ValidationIssue{Code: CodeInvalidRepository, Severity: SeverityError, XPath: repositoryXPath + "/corpname[1]", Value: "Partner Archives"}, issues[0])
+
+	config.ValidRepositoryNames = []string{"Partner Archives"}
+	issues, err = rule.Check(makeTestDocument("", "Partner Archives", ""), &config)
+	failOnError(t, err)
+	if len(issues) != 0 {
+		t.Errorf("Expected no issues, got %v", issues)
+	}
+
+	config.ValidRepositoryNames = nil
+	issues, err = rule.Check(makeTestDocument("", "Any Archives", ""), &config)
+	failOnError(t, err)
+	if len(issues) != 0 {
+		t.Errorf("Expected no issues, got %v", issues)
+	}
+}
+
+func TestRuleArchDescLevelInIsolation(t *testing.T) {
+	rule := getRule(t, DefaultRules(), RuleArchDescLevel)
+	config := DefaultConfig()
+
+	issues, err := rule.Check(makeTestDocument("", "", "fonds"), &config)
+	failOnError(t, err)
+	if len(issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %v", issues)
+	}
+	assertIssue(t, ValidationIssue{Code: CodeInvalidArchDescLevel, Severity: SeverityError, XPath: archDescXPath + "/@level", Value: "fonds"}, issues[0])
+
+	config.ArchDescRequiredLevel = "fonds"
+	issues, err = rule.Check(makeTestDocument("", "", "fonds"), &config)
+	failOnError(t, err)
+	if len(issues) != 0 {
+		t.Errorf("Expected no issues, got %v", issues)
+	}
+
+	config.ArchDescRequiredLevel = ""
+	issues, err = rule.Check(makeTestDocument("", "", "series"), &config)
+	failOnError(t, err)
+	if len(issues) != 0 {
+		t.Errorf("Expected no issues with the check disabled, got %v", issues)
+	}
+}
+
+func TestValidatorPartnerProfile(t *testing.T) {
+	config := DefaultConfig()
+	config.ValidRepositoryNames = []string{"NYU Archives"}
+	validator := NewValidator(config)
+
+	if !validator.RemoveRule(RuleEADID) {
+		t.Errorf(`Expected rule "%s" to be removed`, RuleEADID)
+	}
+	if validator.RemoveRule("no-such-rule") {
+		t.Errorf("Expected removal of an unknown rule to fail")
+	}
+
+	var customRuleCalled bool
+	validator.AddRule(NewRule("custom", func(doc *Document, config *Config) ([]ValidationIssue, error) {
+		customRuleCalled = true
+		return nil, nil
+	}))
+
+	result, err := validator.ValidateFile(invalidEadDataFixturePath)
+	failOnError(t, err)
+
+	// the default profile reports invalid <eadid> and <repository> issues for this fixture,
+	// the partner profile only reports the unpublished material
+	if len(result.Issues) != 2 {
+		t.Fatalf("Expected 2 issues, got %d: %v", len(result.Issues), result.Issues)
+	}
+	for _, issue := range result.Issues {
+		assertEqual(t, CodeUnpublishedMaterial, issue.Code, "issue code")
+	}
+	if !customRuleCalled {
+		t.Errorf("Expected custom rule to be called")
+	}
+}
+
+func TestValidatorPrerequisiteFailureStopsValidation(t *testing.T) {
+	validator := NewDefaultValidator()
+
+	var ruleCalled bool
+	validator.AddRule(NewRule("custom", func(doc *Document, config *Config) ([]ValidationIssue, error) {
+		ruleCalled = true
+		return nil, nil
+	}))
+
+	result, err := validator.Validate(getEADXML(invalidXMLFixturePath))
+	failOnError(t, err)
+
+	if len(result.Issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d: %v", len(result.Issues), result.Issues)
+	}
+	if ruleCalled {
+		t.Errorf("Expected rules to be skipped after a prerequisite failure")
+	}
+}
+
+func TestValidatorMaximumFileSize(t *testing.T) {
+	config := DefaultConfig()
+	config.MaximumFileSize = 1000
+	validator := NewValidator(config)
+
+	fileInfo, err := os.Stat(validEADFixturePath)
+	failOnError(t, err)
+
+	result, err := validator.ValidateFile(validEADFixturePath)
+	failOnError(t, err)
+
+	if len(result.Issues) != 1 {
+		t.Fatalf("Expected 1 issue, got %d: %v", len(result.Issues), result.Issues)
+	}
+	assertEqual(t, makeFileTooBigErrorMessage(validEADFixturePath, fileInfo.Size(), 1000), result.Issues[0].Message, "file too big message")
+}
+
+func failOnError(t *testing.T, err error) {
+	if err != nil {
+		t.Fatalf("Unexpected runtime error: %s", err)
+	}
+}
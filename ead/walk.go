@@ -0,0 +1,134 @@
+package ead
+
+import (
+	"errors"
+)
+
+// SkipChildren is used as a return value from a Visitor's Pre function to
+// indicate that the children of the component are to be skipped.  It is not
+// returned as an error by any function.
+var SkipChildren = errors.New("skip children")
+
+// SkipAll is used as a return value from a WalkFunc to indicate that all
+// remaining components are to be skipped.  It is not returned as an error
+// by any function.
+var SkipAll = errors.New("skip all")
+
+// WalkInfo describes the position of a component in the component hierarchy
+type WalkInfo struct {
+	// Depth is 1 for top-level components
+	Depth int
+	// Index is the index of the component among its siblings
+	Index int
+	// Parents is the parent chain of the component, starting with the
+	// top-level component.  It is empty for top-level components.
+	// Parents is reused during the walk: copy it to retain it.
+	Parents []*C
+}
+
+// Parent returns the parent component, or nil for top-level components
+func (wi *WalkInfo) Parent() *C {
+	if len(wi.Parents) == 0 {
+		return nil
+	}
+	return wi.Parents[len(wi.Parents)-1]
+}
+
+// WalkFunc is the type of the function called for each component.
+//
+// If the function returns SkipAll, the walk stops and returns nil.  If it
+// returns any other non-nil error, the walk stops and returns that error.
+type WalkFunc func(c *C, info *WalkInfo) error
+
+// Visitor holds the functions called for each component during a walk:
+// Pre is called before the component's children are visited (pre-order),
+// and Post is called after (post-order).  Either may be nil.
+//
+// If Pre returns SkipChildren, the component's children are skipped, but
+// Post is still called for the component.
+type Visitor struct {
+	Pre  WalkFunc
+	Post WalkFunc
+}
+
+// WalkCs walks the component hierarchy in pre-order, calling fn for each component
+func WalkCs(cs []*C, fn WalkFunc) error {
+	return VisitCs(cs, Visitor{Pre: fn})
+}
+
+// VisitCs walks the component hierarchy, calling the Visitor's Pre and Post
+// functions for each component
+func VisitCs(cs []*C, v Visitor) error {
+	w := walker{visitor: v}
+	err := w.visitCs(cs)
+	if err == SkipAll {
+		return nil
+	}
+	return err
+}
+
+// WalkComponents walks all components of the EAD in pre-order
+func (e *EAD) WalkComponents(fn WalkFunc) error {
+	return e.VisitComponents(Visitor{Pre: fn})
+}
+
+// VisitComponents walks all components of the EAD with the Visitor
+func (e *EAD) VisitComponents(v Visitor) error {
+	if e.ArchDesc == nil || e.ArchDesc.DSC == nil {
+		return nil
+	}
+	return VisitCs(e.ArchDesc.DSC.C, v)
+}
+
+type walker struct {
+	visitor Visitor
+	parents []*C
+}
+
+// process an array of components
+func (w *walker) visitCs(cs []*C) error {
+	for i, c := range cs {
+		err := w.visitC(c, i)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// process a component
+func (w *walker) visitC(c *C, index int) error {
+	info := WalkInfo{
+		Depth:   len(w.parents) + 1,
+		Index:   index,
+		Parents: w.parents[:len(w.parents):len(w.parents)],
+	}
+
+	skipChildren := false
+	if w.visitor.Pre != nil {
+		err := w.visitor.Pre(c, &info)
+		if err == SkipChildren {
+			skipChildren = true
+		} else if err != nil {
+			return err
+		}
+	}
+
+	if !skipChildren {
+		w.parents = append(w.parents, c)
+		err := w.visitCs(c.C)
+		w.parents = w.parents[:len(w.parents)-1]
+		if err != nil {
+			return err
+		}
+	}
+
+	if w.visitor.Post != nil {
+		err := w.visitor.Post(c, &info)
+		if err != nil && err != SkipChildren {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,199 @@
+package ead
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// getWalkTestCs returns the component hierarchy a(a1(a1x), a2), b(b1)
+func getWalkTestCs() []*C {
+	return []*C{
+		{ID: "a", C: []*C{
+			{ID: "a1", C: []*C{{ID: "a1x"}}},
+			{ID: "a2"},
+		}},
+		{ID: "b", C: []*C{{ID: "b1"}}},
+	}
+}
+
+func formatWalkInfo(c *C, info *WalkInfo) string {
+	var parents []string
+	for _, parent := range info.Parents {
+		parents = append(parents, string(parent.ID))
+	}
+	return fmt.Sprintf("%s:%d:%d:%s", c.ID, info.Depth, info.Index, strings.Join(parents, "/"))
+}
+
+func TestWalkCs(t *testing.T) {
+	t.Run("WalkCs", func(t *testing.T) {
+		var got []string
+		err := WalkCs(getWalkTestCs(), func(c *C, info *WalkInfo) error {
+			got = append(got, formatWalkInfo(c, info))
+			return nil
+		})
+		failOnError(t, err, "Unexpected error walking components")
+
+		want := "a:1:0: a1:2:0:a a1x:3:0:a/a1 a2:2:1:a b:1:1: b1:2:0:b"
+		assertEqual(t, want, strings.Join(got, " "), "Pre-order walk")
+	})
+}
+
+func TestVisitCs(t *testing.T) {
+	t.Run("VisitCs", func(t *testing.T) {
+		var got []string
+		err := VisitCs(getWalkTestCs(), Visitor{
+			Pre: func(c *C, info *WalkInfo) error {
+				got = append(got, "+"+string(c.ID))
+				return nil
+			},
+			Post: func(c *C, info *WalkInfo) error {
+				got = append(got, "-"+string(c.ID))
+				return nil
+			},
+		})
+		failOnError(t, err, "Unexpected error visiting components")
+
+		want := "+a +a1 +a1x -a1x -a1 +a2 -a2 -a +b +b1 -b1 -b"
+		assertEqual(t, want, strings.Join(got, " "), "Pre- and post-order visit")
+
+		got = nil
+		err = VisitCs(getWalkTestCs(), Visitor{
+			Post: func(c *C, info *WalkInfo) error {
+				got = append(got, formatWalkInfo(c, info))
+				return nil
+			},
+		})
+		failOnError(t, err, "Unexpected error visiting components")
+
+		want = "a1x:3:0:a/a1 a1:2:0:a a2:2:1:a a:1:0: b1:2:0:b b:1:1:"
+		assertEqual(t, want, strings.Join(got, " "), "Post-order visit")
+	})
+}
+
+func TestWalkCsSkipChildren(t *testing.T) {
+	t.Run("WalkCs SkipChildren", func(t *testing.T) {
+		var got []string
+		err := VisitCs(getWalkTestCs(), Visitor{
+			Pre: func(c *C, info *WalkInfo) error {
+				got = append(got, "+"+string(c.ID))
+				if c.ID == "a1" || c.ID == "b" {
+					return SkipChildren
+				}
+				return nil
+			},
+			Post: func(c *C, info *WalkInfo) error {
+				got = append(got, "-"+string(c.ID))
+				return nil
+			},
+		})
+		failOnError(t, err, "Unexpected error visiting components")
+
+		want := "+a +a1 -a1 +a2 -a2 -a +b -b"
+		assertEqual(t, want, strings.Join(got, " "), "Visit skipping children")
+	})
+}
+
+func TestWalkCsEarlyTermination(t *testing.T) {
+	t.Run("WalkCs Early Termination", func(t *testing.T) {
+		var got []string
+		err := WalkCs(getWalkTestCs(), func(c *C, info *WalkInfo) error {
+			got = append(got, string(c.ID))
+			if c.ID == "a1x" {
+				return SkipAll
+			}
+			return nil
+		})
+		failOnError(t, err, "Unexpected error walking components")
+		assertEqual(t, "a a1 a1x", strings.Join(got, " "), "Walk stopped with SkipAll")
+
+		got = nil
+		errStop := errors.New("stop")
+		err = VisitCs(getWalkTestCs(), Visitor{Post: func(c *C, info *WalkInfo) error {
+			got = append(got, string(c.ID))
+			if c.ID == "a" {
+				return errStop
+			}
+			return nil
+		}})
+		if err != errStop {
+			t.Errorf("Expected the WalkFunc error to be returned, got %v", err)
+		}
+		assertEqual(t, "a1x a1 a2 a", strings.Join(got, " "), "Visit stopped with an error")
+	})
+}
+
+func TestWalkInfoParents(t *testing.T) {
+	t.Run("WalkInfo Parents", func(t *testing.T) {
+		cs := getWalkTestCs()
+		var a1xParent, bParent *C
+		err := WalkCs(cs, func(c *C, info *WalkInfo) error {
+			// appending to Parents must not corrupt the walk
+			_ = append(info.Parents, &C{ID: "bogus"})
+
+			switch c.ID {
+			case "a1x":
+				a1xParent = info.Parent()
+			case "b":
+				bParent = info.Parent()
+			case "b1":
+				if info.Parents[0] != cs[1] {
+					t.Errorf("Expected b1's top-level parent to be b, got %s", info.Parents[0].ID)
+				}
+			}
+			return nil
+		})
+		failOnError(t, err, "Unexpected error walking components")
+
+		if a1xParent != cs[0].C[0] {
+			t.Errorf("Expected a1x's parent to be a1")
+		}
+		if bParent != nil {
+			t.Errorf("Expected top-level component b to have no parent")
+		}
+	})
+}
+
+func TestEADWalkComponents(t *testing.T) {
+	t.Run("EAD WalkComponents", func(t *testing.T) {
+		sut := getOmegaEAD(t)
+
+		var count, maxDepth int
+		err := sut.WalkComponents(func(c *C, info *WalkInfo) error {
+			count += 1
+			if info.Depth > maxDepth {
+				maxDepth = info.Depth
+			}
+			return nil
+		})
+		failOnError(t, err, "Unexpected error walking components")
+		if count != 17 || maxDepth != 6 {
+			t.Errorf("Expected 17 components with a maximum depth of 6, got %d and %d", count, maxDepth)
+		}
+
+		// the DAO counters aggregate in pre-order
+		sut.InitDAOCounts()
+		var want []*DAO
+		want = append(want, sut.ArchDesc.DID.DAO...)
+		sut.WalkComponents(func(c *C, info *WalkInfo) error {
+			want = append(want, c.DID.DAO...)
+			return nil
+		})
+		if len(want) != len(sut.DAOInfo.AllDAOs) {
+			t.Fatalf("Expected %d DAOs, got %d", len(want), len(sut.DAOInfo.AllDAOs))
+		}
+		for i := range want {
+			if want[i] != sut.DAOInfo.AllDAOs[i] {
+				t.Errorf("Unexpected DAO order at index %d", i)
+			}
+		}
+
+		sut = EAD{}
+		err = sut.WalkComponents(func(c *C, info *WalkInfo) error {
+			t.Errorf("Unexpected component")
+			return nil
+		})
+		failOnError(t, err, "Unexpected error walking an EAD without components")
+	})
+}
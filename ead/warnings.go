@@ -0,0 +1,328 @@
+package ead
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// WarningKind is the kind of a lenient decoding Warning
+type WarningKind string
+
+const (
+	// SkippedElement is an element that is not part of the data model, and
+	// was dropped with its content
+	SkippedElement WarningKind = "skipped element"
+	// SkippedAttribute is an attribute that is not part of the data model
+	SkippedAttribute WarningKind = "skipped attribute"
+	// RawElementPreserved is an element that is not supported by EADChild,
+	// and was preserved as a RawElement
+	RawElementPreserved WarningKind = "unsupported element preserved as raw element"
+	// FlattenedElement is an element in inner XML that is preserved, e.g.,
+	// a <genreform> in a <physdesc>, but that is not part of the data model.
+	// Its content is only kept in the rendered HTML of the iJSON "value".
+	// The elements with their own rendering, e.g., <emph>, are not
+	// reported.
+	FlattenedElement WarningKind = "element flattened into inline content"
+	// FlattenedAttribute is an attribute of a FlattenedElement that is not
+	// rendered
+	FlattenedAttribute WarningKind = "attribute of flattened element"
+)
+
+// Warning records an element or attribute of the source EAD that did not
+// make it into the data model as-is
+type Warning struct {
+	Kind WarningKind `json:"kind"`
+
+	// Path is the fully positional XPath of the element or attribute, like
+	// the validation issue XPaths, e.g.,
+	// "/ead[1]/archdesc[1]/dsc[1]/c[2]/did[1]/unitid[1]/@repositorycode"
+	Path string `json:"path"`
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("%s: %s", w.Kind, w.Path)
+}
+
+// getWarnings walks the XML token stream alongside the data model types,
+// and returns a Warning for each element and attribute that xml.Unmarshal
+// drops, and for each element that lenient decoding preserves as a
+// RawElement.  The content of skipped elements is not reported separately.
+// If renderer is not nil, the FlattenedElement and FlattenedAttribute
+// warnings for the content of preserved inner XML that it does not render
+// are also returned.
+func getWarnings(data []byte, renderer *Renderer) ([]Warning, error) {
+	w := &modelWalker{d: xml.NewDecoder(bytes.NewReader(data)), renderer: renderer}
+	hasRoot := false
+	for {
+		token, err := w.d.RawToken()
+		if err == io.EOF && !hasRoot {
+			return nil, fmt.Errorf("missing root element")
+		} else if err == io.EOF {
+			return w.warnings, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		if start, ok := token.(xml.StartElement); ok {
+			hasRoot = true
+			err = w.walkElement(start, reflect.TypeOf(EAD{}), "/"+qualifiedXMLName(start.Name)+"[1]")
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+type modelWalker struct {
+	d *xml.Decoder
+	// renderer is nil if the flattened elements are not reported
+	renderer *Renderer
+	warnings []Warning
+}
+
+func (w *modelWalker) warn(kind WarningKind, path string) {
+	w.warnings = append(w.warnings, Warning{Kind: kind, Path: path})
+}
+
+// walkElement walks the content of the element, which is decoded into a
+// value of type t
+func (w *modelWalker) walkElement(start xml.StartElement, t reflect.Type, path string) error {
+	model := getXMLModel(t)
+
+	for _, attr := range start.Attr {
+		if isNamespaceAttr(attr) || model.anyAttr || model.attrs[attr.Name.Local] {
+			continue
+		}
+		w.warn(SkippedAttribute, path+"/@"+qualifiedXMLName(attr.Name))
+	}
+
+	// the inner XML is preserved as-is
+	if model.innerXML && w.renderer == nil {
+		return w.skip()
+	}
+
+	siblings := map[string]int{}
+	for {
+		token, err := w.d.RawToken()
+		if err != nil {
+			return err
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			childPath := siblingPath(path, token.Name, siblings)
+			childType, ok := model.elements[token.Name.Local]
+			if !ok && model.anyElement != nil {
+				childType, ok = model.anyElement, true
+				if childType == reflect.TypeOf(EADChild{}) {
+					if value := newEADChildValue(token.Name.Local); value != nil {
+						childType = reflect.TypeOf(value).Elem()
+					} else {
+						// the attributes and content are preserved as-is
+						w.warn(RawElementPreserved, childPath)
+						if err := w.skip(); err != nil {
+							return err
+						}
+						continue
+					}
+				}
+			}
+
+			if !ok && model.innerXML {
+				if err := w.walkFlattenedElement(token, childPath); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if !ok {
+				w.warn(SkippedElement, childPath)
+				if err := w.skip(); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err := w.walkElement(token, childType, childPath); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// walkFlattenedElement walks an element in preserved inner XML that is not
+// part of the data model.  The elements with their own rendering, e.g.,
+// <emph> and <lb/>, are part of the rendered HTML, like the rendered
+// attributes, so they are not reported.
+func (w *modelWalker) walkFlattenedElement(start xml.StartElement, path string) error {
+	if !w.renderer.rendersElement(start.Name.Local) {
+		w.warn(FlattenedElement, path)
+	}
+
+	for _, attr := range start.Attr {
+		if isNamespaceAttr(attr) || w.renderer.rendersAttribute(start.Name.Local, attr.Name.Local) {
+			continue
+		}
+		w.warn(FlattenedAttribute, path+"/@"+qualifiedXMLName(attr.Name))
+	}
+
+	siblings := map[string]int{}
+	for {
+		token, err := w.d.RawToken()
+		if err != nil {
+			return err
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			childPath := siblingPath(path, token.Name, siblings)
+			if err := w.walkFlattenedElement(token, childPath); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// siblingPath returns the path of a child element with its position among
+// the siblings with that name
+func siblingPath(path string, name xml.Name, siblings map[string]int) string {
+	qualifiedName := qualifiedXMLName(name)
+	siblings[qualifiedName]++
+	return fmt.Sprintf("%s/%s[%d]", path, qualifiedName, siblings[qualifiedName])
+}
+
+// skip skips the content of the current element.  xml.Decoder.Skip() can
+// not be used with RawToken(), which does not check that the elements match.
+func (w *modelWalker) skip() error {
+	for depth := 0; ; {
+		token, err := w.d.RawToken()
+		if err != nil {
+			return err
+		}
+		switch token.(type) {
+		case xml.StartElement:
+			depth++
+		case xml.EndElement:
+			if depth == 0 {
+				return nil
+			}
+			depth--
+		}
+	}
+}
+
+func isNamespaceAttr(attr xml.Attr) bool {
+	// xsi:schemaLocation is schema plumbing rather than content
+	return attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" || attr.Name.Space == "xsi"
+}
+
+// xmlModel holds the element and attribute names that xml.Unmarshal maps
+// to the fields of a type
+type xmlModel struct {
+	attrs   map[string]bool
+	anyAttr bool
+
+	// elements maps element names to the field types, with the pointers
+	// and slices removed
+	elements   map[string]reflect.Type
+	anyElement reflect.Type
+
+	// innerXML is set if the inner XML is preserved as-is in the iJSON
+	innerXML bool
+}
+
+var xmlModels sync.Map
+
+func getXMLModel(t reflect.Type) *xmlModel {
+	if model, ok := xmlModels.Load(t); ok {
+		return model.(*xmlModel)
+	}
+
+	model := &xmlModel{attrs: map[string]bool{}, elements: map[string]reflect.Type{}}
+	// elements that are not decoded into a struct, e.g., a FilteredString,
+	// only keep their character data
+	if t.Kind() == reflect.Struct {
+		addXMLModelFields(model, t)
+	}
+	// the numbered components are decoded by the DSC and C UnmarshalXML
+	if t == reflect.TypeOf(DSC{}) || t == reflect.TypeOf(C{}) {
+		addXMLModelFields(model, reflect.TypeOf(numberedComponents{}))
+	}
+
+	xmlModels.Store(t, model)
+	return model
+}
+
+func addXMLModelFields(model *xmlModel, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("xml")
+		if tag == "-" {
+			continue
+		}
+
+		// xml.Unmarshal flattens embedded structs without an xml tag
+		if sf.Anonymous && tag == "" {
+			ft := sf.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				addXMLModelFields(model, ft)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+
+		name, flags, _ := strings.Cut(tag, ",")
+		flagSet := map[string]bool{}
+		for _, flag := range strings.Split(flags, ",") {
+			flagSet[flag] = true
+		}
+
+		switch {
+		case flagSet["innerxml"]:
+			// inner XML that is not marshaled, e.g., the FormattedNoteWithHead
+			// Value, does not preserve the content
+			if sf.Tag.Get("json") != "-" {
+				model.innerXML = true
+			}
+		case flagSet["attr"] && flagSet["any"]:
+			model.anyAttr = true
+		case flagSet["attr"]:
+			if name == "" {
+				name = sf.Name
+			}
+			model.attrs[name] = true
+		case flagSet["any"]:
+			model.anyElement = elementType(sf.Type)
+		case flagSet["chardata"], flagSet["cdata"], flagSet["comment"]:
+		default:
+			// fields without an xml tag are matched by field name
+			if name == "" {
+				name = sf.Name
+			}
+			model.elements[name] = elementType(sf.Type)
+		}
+	}
+}
+
+// elementType removes the pointers and slices of a field type, e.g.,
+// []*P is decoded from <p> elements decoded into a P
+func elementType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer || (t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8) {
+		t = t.Elem()
+	}
+	return t
+}
@@ -8,4 +8,5 @@ require (
 	github.com/lestrrat-go/libxml2 v0.0.0-20201123224832-e6d9de61b80d
 	github.com/pkg/errors v0.9.1 // indirect
 	golang.org/x/text v0.7.0
+	gopkg.in/yaml.v3 v3.0.1
 )